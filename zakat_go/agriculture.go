@@ -0,0 +1,178 @@
+package zakat
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// agricultureNisabKg is the produce nisab: 5 awsuq (Sahih Muslim 979),
+	// approx. 653 kg per Dr. Yusuf al-Qaradawi (Fiqh al-Zakah).
+	agricultureNisabKg = decimal.NewFromInt(653)
+	// rainFedRate is the full ushr on naturally watered land (Sahih al-Bukhari 1483).
+	rainFedRate = decimal.RequireFromString("0.10")
+	// irrigatedRate is the half ushr on costly irrigation (Sahih Muslim 981).
+	irrigatedRate = decimal.RequireFromString("0.05")
+	// mixedIrrigationRate applies when both methods are used (ijtihad).
+	mixedIrrigationRate = decimal.RequireFromString("0.075")
+)
+
+// Irrigation methods accepted in AgricultureInput.Irrigation.
+const (
+	IrrigationRain      = "Rain"
+	IrrigationIrrigated = "Irrigated"
+	IrrigationMixed     = "Mixed"
+)
+
+// AgricultureNisabPolicy decides whether a harvest must reach the produce
+// nisab before the ushr is due.
+type AgricultureNisabPolicy string
+
+const (
+	// AgricultureStandardNisab requires the harvest to reach 5 awsuq
+	// (about 653 kg), the view of the majority. This is the default.
+	AgricultureStandardNisab AgricultureNisabPolicy = ""
+	// AgricultureNoNisab zakats any harvest however small, the view of Abu
+	// Hanifa based on the general wording of "whatever the sky waters" (Sahih
+	// al-Bukhari 1483).
+	AgricultureNoNisab AgricultureNisabPolicy = "none"
+)
+
+func (p AgricultureNisabPolicy) validate() error {
+	switch p {
+	case AgricultureStandardNisab, AgricultureNoNisab:
+		return nil
+	default:
+		return fieldError(ErrInvalidOption, "agriculture_nisab_policy", string(p))
+	}
+}
+
+// AgricultureInput holds a harvest of crops or fruit.
+type AgricultureInput struct {
+	// HarvestWeightKg - harvested weight in kilograms
+	HarvestWeightKg string
+	// PricePerKg - market price per kilogram
+	PricePerKg string
+	// Irrigation - "Rain", "Irrigated" or "Mixed". Empty means "Rain".
+	Irrigation string
+	// CultivationCosts - deductible costs (seed, fertilizer, labor)
+	CultivationCosts string
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HeldForSale - whether the produce is stored past harvest for sale,
+	// see Config.StoredProduceAsTradeGoods
+	HeldForSale bool
+	// HawlSatisfied - whether one lunar year has passed; only used when the
+	// produce is treated as trade goods; the in-kind rate is due at harvest
+	HawlSatisfied bool
+}
+
+// Validate checks that all agriculture amounts are valid non-negative
+// decimals and the irrigation method is known.
+func (in AgricultureInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// agricultureValues holds the parsed fields of an AgricultureInput.
+type agricultureValues struct {
+	weight, price, costs, liabilities, disputed decimal.Decimal
+	rate                                        decimal.Decimal
+}
+
+func (in AgricultureInput) parse() (v agricultureValues, err error) {
+	if v.weight, err = parseAmount("harvest_weight_kg", in.HarvestWeightKg); err != nil {
+		return
+	}
+	if v.price, err = parseAmount("price_per_kg", in.PricePerKg); err != nil {
+		return
+	}
+	if v.costs, err = parseAmount("cultivation_costs", in.CultivationCosts); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	if v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities); err != nil {
+		return
+	}
+	switch strings.TrimSpace(in.Irrigation) {
+	case "", IrrigationRain:
+		v.rate = rainFedRate
+	case IrrigationIrrigated:
+		v.rate = irrigatedRate
+	case IrrigationMixed:
+		v.rate = mixedIrrigationRate
+	default:
+		err = fieldError(ErrInvalidOption, "irrigation", in.Irrigation)
+	}
+	return
+}
+
+// CalculateAgriculture calculates zakat on a harvest.
+//
+// By default the ushr is due at harvest: 10%, 5% or 7.5% of the net harvest
+// value by irrigation method, when the harvest reaches 653 kg worth. No hawl
+// applies. Under Config.AgricultureNisabPolicy AgricultureNoNisab the ushr
+// is due on any harvest.
+//
+// Under Config.StoredProduceAsTradeGoods, produce held past harvest for sale
+// is valued as trade goods instead: 2.5% of its net value against the
+// monetary nisab, once a hawl has passed.
+func CalculateAgriculture(input AgricultureInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if err := config.AgricultureNisabPolicy.validate(); err != nil {
+		return ZakatResult{}, err
+	}
+
+	gross := v.weight.Mul(v.price)
+	breakdown := []BreakdownLine{
+		quantityLine("step-harvest-weight", "Harvest Weight (kg)", v.weight, OpAdd, UnitKg),
+		amountLine("step-price-per-kg", "Price per kg", v.price, OpAdd),
+		amountLine("step-total-harvest-value", "Gross Harvest Value", gross, OpResult),
+	}
+	net := gross
+	if v.costs.IsPositive() {
+		net = decimal.Max(gross.Sub(v.costs), decimal.Zero)
+		breakdown = append(breakdown,
+			amountLine("step-deduct-costs", "Cultivation Costs", v.costs, OpSubtract),
+			amountLine("step-net-after-costs", "Net Value (After Costs)", net, OpResult),
+		)
+	}
+
+	params := monetaryParams{
+		totalAssets:   net,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         agricultureNisabKg.Mul(v.price),
+		rate:          v.rate,
+		hawlSatisfied: true,
+		assetType:     AssetTypeAgriculture,
+		breakdown:     breakdown,
+		config:        config,
+	}
+	if config.AgricultureNisabPolicy == AgricultureNoNisab {
+		params.nisab = decimal.Zero
+		params.assumptions = []string{"No produce nisab applied: the ushr is due on any harvest (AgricultureNoNisab)."}
+	}
+	if config.StoredProduceAsTradeGoods && input.HeldForSale {
+		rules, err := config.rules()
+		if err != nil {
+			return ZakatResult{}, err
+		}
+		if params.nisab, err = monetaryNisab(config, rules); err != nil {
+			return ZakatResult{}, err
+		}
+		params.rate = rules.tradeGoodsRate
+		params.hawlSatisfied = input.HawlSatisfied
+		params.breakdown = append(params.breakdown, infoLine(tradeGoodsProduceKey, "Stored for sale: valued as trade goods"))
+		params.assumptions = []string{"Produce held past harvest for sale valued as trade goods at 2.5% (StoredProduceAsTradeGoods)."}
+	}
+	return calculateMonetary(params)
+}
@@ -0,0 +1,78 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculateAgricultureInKind(t *testing.T) {
+	input := AgricultureInput{HarvestWeightKg: "1000", PricePerKg: "2", Irrigation: IrrigationIrrigated, HeldForSale: true}
+	result, err := CalculateAgriculture(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("harvest above 653 kg should be payable")
+	}
+	assertDecimalEqual(t, result.NisabThreshold, "1306", "nisab should be 653 kg worth")
+	assertDecimalEqual(t, result.ZakatDue, "100", "irrigated harvest owes 5%")
+}
+
+func TestCalculateAgricultureStoredAsTradeGoods(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.StoredProduceAsTradeGoods = true
+	input := AgricultureInput{HarvestWeightKg: "1000", PricePerKg: "2", Irrigation: IrrigationIrrigated, HeldForSale: true, HawlSatisfied: true}
+
+	result, err := CalculateAgriculture(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NisabThreshold, "595", "trade goods use the monetary nisab")
+	assertDecimalEqual(t, result.ZakatDue, "50", "stored produce owes 2.5% on value")
+
+	input.HeldForSale = false
+	result, err = CalculateAgriculture(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "100", "produce not held for sale keeps the in-kind rate")
+}
+
+func TestCalculateAgricultureInvalidIrrigation(t *testing.T) {
+	if err := (AgricultureInput{Irrigation: "Sprinkler"}).Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestCalculateAgricultureNisabPolicy(t *testing.T) {
+	small := AgricultureInput{HarvestWeightKg: "200", PricePerKg: "2"}
+	config := NewConfig("100", "1")
+
+	standard, err := CalculateAgriculture(small, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if standard.IsPayable {
+		t.Error("a 200 kg harvest is below the standard nisab")
+	}
+	assertDecimalEqual(t, standard.ZakatDue, "0", "standard policy zakat_due mismatch")
+
+	config.AgricultureNisabPolicy = AgricultureNoNisab
+	noNisab, err := CalculateAgriculture(small, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !noNisab.IsPayable {
+		t.Error("any harvest should be payable without a nisab")
+	}
+	assertDecimalEqual(t, noNisab.ZakatDue, "40", "rain-fed harvest owes 10% of 400")
+	assertDecimalEqual(t, noNisab.NisabThreshold, "0", "no nisab should be reported")
+	if len(noNisab.Assumptions) == 0 {
+		t.Error("expected an assumption noting the minority view")
+	}
+
+	config.AgricultureNisabPolicy = "minimal"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
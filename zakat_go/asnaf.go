@@ -0,0 +1,36 @@
+package zakat
+
+// RecipientCategory is one of the eight categories (asnaf) of people zakat
+// may be given to, named in Surah at-Tawbah 9:60.
+type RecipientCategory struct {
+	// Key - stable identifier, e.g. "fuqara"
+	Key string
+}
+
+// recipientKeys are the eight asnaf in the order of the verse.
+var recipientKeys = []string{
+	"fuqara", "masakin", "amil", "muallaf", "riqab", "gharimin", "fisabilillah", "ibnusabil",
+}
+
+// RecipientCategories lists the eight eligible recipient categories in the
+// order of the verse, to guide donors on where zakat may go. It is reference
+// data only and plays no part in any calculation.
+func RecipientCategories() []RecipientCategory {
+	categories := make([]RecipientCategory, len(recipientKeys))
+	for i, key := range recipientKeys {
+		categories[i] = RecipientCategory{Key: key}
+	}
+	return categories
+}
+
+// Name returns the category's display name in lang ("en" or "id"); an
+// unknown lang falls back to English.
+func (c RecipientCategory) Name(lang string) string {
+	return localeOrEnglish(lang).labels["asnaf."+c.Key]
+}
+
+// Description returns a one-sentence description of who the category covers
+// in lang ("en" or "id"); an unknown lang falls back to English.
+func (c RecipientCategory) Description(lang string) string {
+	return localeOrEnglish(lang).labels["asnaf."+c.Key+".desc"]
+}
@@ -0,0 +1,29 @@
+package zakat
+
+import "testing"
+
+func TestRecipientCategories(t *testing.T) {
+	categories := RecipientCategories()
+	if len(categories) != 8 {
+		t.Fatalf("expected the eight asnaf, got %d", len(categories))
+	}
+	for _, lang := range []string{"en", "id"} {
+		seen := map[string]bool{}
+		for _, category := range categories {
+			name := category.Name(lang)
+			if name == "" || category.Description(lang) == "" {
+				t.Errorf("%s: category %q has no name or description", lang, category.Key)
+			}
+			if seen[name] {
+				t.Errorf("%s: duplicate name %q", lang, name)
+			}
+			seen[name] = true
+		}
+	}
+	if got := categories[0].Name("id"); got != "Fakir" {
+		t.Errorf("expected Fakir, got %q", got)
+	}
+	if categories[7].Name("fr") != categories[7].Name("en") {
+		t.Errorf("an unknown lang should fall back to English")
+	}
+}
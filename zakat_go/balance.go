@@ -0,0 +1,35 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MinBalanceOverPeriod returns the lowest of the daily balances, the amount
+// that stayed in the owner's hands throughout the period.
+func MinBalanceOverPeriod(dailyBalances []string) (string, error) {
+	minimum, err := minBalance(dailyBalances)
+	if err != nil {
+		return "", err
+	}
+	return minimum.String(), nil
+}
+
+// minBalance parses the daily balances and returns the lowest.
+func minBalance(dailyBalances []string) (decimal.Decimal, error) {
+	if len(dailyBalances) == 0 {
+		return decimal.Zero, fieldError(ErrInconsistentInput, "daily_balances", "")
+	}
+	var minimum decimal.Decimal
+	for i, s := range dailyBalances {
+		balance, err := parseAmount(fmt.Sprintf("daily_balances[%d]", i), s)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if i == 0 || balance.LessThan(minimum) {
+			minimum = balance
+		}
+	}
+	return minimum, nil
+}
@@ -0,0 +1,90 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMinBalanceOverPeriod(t *testing.T) {
+	got, err := MinBalanceOverPeriod([]string{"9000", "400.50", "12000"})
+	if err != nil {
+		t.Fatalf("MinBalanceOverPeriod failed: %v", err)
+	}
+	assertDecimalEqual(t, got, "400.50", "minimum balance mismatch")
+
+	if _, err := MinBalanceOverPeriod(nil); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput for no balances, got %v", err)
+	}
+	if _, err := MinBalanceOverPeriod([]string{"100", "abc"}); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal, got %v", err)
+	}
+}
+
+func TestUseMinimumBalanceBelowNisab(t *testing.T) {
+	input := CashInput{
+		CashOnHand:    "12000",
+		DailyBalances: []string{"8000", "300", "12000"},
+		HawlSatisfied: true,
+	}
+	config := NewConfig("100", "1")
+
+	yearEnd, err := CalculateCash(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !yearEnd.IsPayable {
+		t.Errorf("year-end balance above nisab should be payable by default")
+	}
+
+	config.UseMinimumBalance = true
+	result, err := CalculateCash(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("minimum balance of 300 is below nisab of 595; should not be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+	assertDecimalEqual(t, result.NetAssets, "12000", "net assets should still be the year-end balance")
+}
+
+func TestUseMinimumBalanceAboveNisab(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.UseMinimumBalance = true
+	result, err := CalculateCash(CashInput{
+		CashOnHand:    "12000",
+		DailyBalances: []string{"8000", "600", "12000"},
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("minimum balance above nisab should be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "300", "zakat is levied on the year-end balance")
+}
+
+func TestUseMinimumBalancePortfolio(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.UseMinimumBalance = true
+	input := PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "12000", DailyBalances: []string{"8000", "300", "12000"}, HawlSatisfied: true}},
+	}
+	result, err := CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("pooled minimum of 300 is below nisab of 595; should not be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+
+	// Other holdings count in full toward the pooled minimum.
+	input.Business = []BusinessInput{{CashOnHand: "1000", HawlSatisfied: true}}
+	result, err = CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "325", "zakat is levied on the year-end pooled net assets")
+}
@@ -0,0 +1,49 @@
+package zakat
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchResult is the outcome of one input in a batch.
+type BatchResult struct {
+	Result ZakatResult
+	Err    error
+}
+
+// CalculateBatch calculates many inputs with a pool of workers. Each input
+// is one of the typed inputs (CashInput, GoldInput, ...); any other value
+// yields ErrUnsupportedInput in its slot.
+//
+// Results are returned in input order, one per input, so results[i] always
+// belongs to inputs[i] whatever order the workers finish in; an error on one
+// input does not stop the others.
+//
+// The pure-Go backend shares nothing mutable between calculations, so the
+// batch runs on GOMAXPROCS workers. Calls into the FFI backend (under
+// Config.VerifyResults) are serialized, which bounds the effective
+// parallelism of that part to one. A Config.NisabResolver is called from
+// several goroutines and must be safe for concurrent use.
+func CalculateBatch(inputs []any, config Config) []BatchResult {
+	results := make([]BatchResult, len(inputs))
+	workers := min(runtime.GOMAXPROCS(0), len(inputs))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// Each worker writes only its own slots, so no lock is needed.
+				results[i].Result, results[i].Err = calculateInput(inputs[i], config)
+			}
+		}()
+	}
+	for i := range inputs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
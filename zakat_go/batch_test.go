@@ -0,0 +1,60 @@
+package zakat
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// batchInputs returns n inputs whose due identifies their index: input i
+// holds 10000+i*40 of cash, so it owes 250+i.
+func batchInputs(n int) []any {
+	inputs := make([]any, n)
+	for i := range inputs {
+		inputs[i] = CashInput{CashOnHand: fmt.Sprint(10000 + i*40), HawlSatisfied: true}
+	}
+	return inputs
+}
+
+func TestCalculateBatchPreservesOrder(t *testing.T) {
+	inputs := batchInputs(500)
+	inputs[7] = CashInput{CashOnHand: "not-a-number"}
+
+	results := CalculateBatch(inputs, NewConfig("100", "1"))
+	if len(results) != len(inputs) {
+		t.Fatalf("expected %d results, got %d", len(inputs), len(results))
+	}
+	for i, r := range results {
+		if i == 7 {
+			if !errors.Is(r.Err, ErrInvalidDecimal) {
+				t.Errorf("result 7: expected ErrInvalidDecimal, got %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Fatalf("result %d: %v", i, r.Err)
+		}
+		assertDecimalEqual(t, r.Result.ZakatDue, fmt.Sprint(250+i), fmt.Sprintf("result %d out of order", i))
+	}
+}
+
+func TestCalculateBatchConcurrentFFIVerification(t *testing.T) {
+	withFFIBackend(t, dispatchInput)
+	config := NewConfig("100", "1")
+	config.VerifyResults = true
+
+	for i, r := range CalculateBatch(batchInputs(200), config) {
+		if r.Err != nil {
+			t.Fatalf("result %d: %v", i, r.Err)
+		}
+	}
+}
+
+func BenchmarkCalculateBatch(b *testing.B) {
+	inputs := batchInputs(1000)
+	config := NewConfig("100", "1")
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		CalculateBatch(inputs, config)
+	}
+}
@@ -0,0 +1,113 @@
+package zakat
+
+// Calculator accumulates assets for fluent multi-asset calculations.
+//
+//	result, err := zakat.Calc(config).
+//	    Business(shop).
+//	    Gold(savings).
+//	    Portfolio()
+type Calculator struct {
+	config Config
+	// components calculate each asset; a non-nil hawl overrides the
+	// asset's own HawlSatisfied (see PortfolioHawlPolicy).
+	components []func(config Config, hawl *bool) (ZakatResult, error)
+	// hawlFlags holds the HawlSatisfied of each monetary asset.
+	hawlFlags []bool
+}
+
+// Calc starts a fluent calculation with the given config.
+func Calc(config Config) *Calculator {
+	return &Calculator{config: config}
+}
+
+// Business adds a business asset.
+func (c *Calculator) Business(input BusinessInput) *Calculator {
+	c.hawlFlags = append(c.hawlFlags, input.HawlSatisfied)
+	c.components = append(c.components, func(config Config, hawl *bool) (ZakatResult, error) {
+		if hawl != nil {
+			input.HawlSatisfied = *hawl
+		}
+		return CalculateBusiness(input, config)
+	})
+	return c
+}
+
+// Gold adds a gold holding.
+func (c *Calculator) Gold(input GoldInput) *Calculator {
+	c.hawlFlags = append(c.hawlFlags, input.HawlSatisfied)
+	c.components = append(c.components, func(config Config, hawl *bool) (ZakatResult, error) {
+		if hawl != nil {
+			input.HawlSatisfied = *hawl
+		}
+		return CalculateGold(input, config)
+	})
+	return c
+}
+
+// Silver adds a silver holding.
+func (c *Calculator) Silver(input SilverInput) *Calculator {
+	c.hawlFlags = append(c.hawlFlags, input.HawlSatisfied)
+	c.components = append(c.components, func(config Config, hawl *bool) (ZakatResult, error) {
+		if hawl != nil {
+			input.HawlSatisfied = *hawl
+		}
+		return CalculateSilver(input, config)
+	})
+	return c
+}
+
+// Cash adds cash and bank balances.
+func (c *Calculator) Cash(input CashInput) *Calculator {
+	c.hawlFlags = append(c.hawlFlags, input.HawlSatisfied)
+	c.components = append(c.components, func(config Config, hawl *bool) (ZakatResult, error) {
+		if hawl != nil {
+			input.HawlSatisfied = *hawl
+		}
+		return CalculateCash(input, config)
+	})
+	return c
+}
+
+// Agriculture adds a harvest. It keeps its own nisab when pooled.
+func (c *Calculator) Agriculture(input AgricultureInput) *Calculator {
+	c.components = append(c.components, func(config Config, _ *bool) (ZakatResult, error) {
+		return CalculateAgriculture(input, config)
+	})
+	return c
+}
+
+// Each calculates every added asset independently, in the order added.
+func (c *Calculator) Each() ([]ZakatResult, error) {
+	return c.calculate(nil)
+}
+
+func (c *Calculator) calculate(hawl *bool) ([]ZakatResult, error) {
+	results := make([]ZakatResult, 0, len(c.components))
+	for _, calculate := range c.components {
+		result, err := calculate(c.config, hawl)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Portfolio calculates every added asset and pools them for one nisab test,
+// as CalculatePortfolio does, including its Config.PortfolioHawlPolicy.
+// Components keep the order they were added.
+func (c *Calculator) Portfolio() (PortfolioResult, error) {
+	hawl, note, err := policyHawl(c.hawlFlags, c.config)
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	components, err := c.calculate(hawl)
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	result, err := poolResults(components, c.config)
+	if err == nil && note != "" {
+		result.Assumptions = append(result.Assumptions, note)
+	}
+	return result, err
+}
@@ -0,0 +1,76 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalcPortfolioTwoComponents(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := Calc(config).
+		Business(BusinessInput{CashOnHand: "5000", HawlSatisfied: true}).
+		Gold(GoldInput{WeightGrams: "40", Purity: "24", HawlSatisfied: true}).
+		Portfolio()
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if len(result.Components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(result.Components))
+	}
+	if result.Components[0].AssetType != AssetTypeBusiness || result.Components[1].AssetType != AssetTypeGold {
+		t.Errorf("components out of order: %s, %s", result.Components[0].AssetType, result.Components[1].AssetType)
+	}
+	if result.Components[0].IsPayable || result.Components[1].IsPayable {
+		t.Errorf("neither component should clear nisab alone")
+	}
+	if !result.IsPayable {
+		t.Errorf("pooled portfolio should clear nisab")
+	}
+	assertDecimalEqual(t, result.NetAssets, "9000", "pooled net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "225", "pooled zakat_due mismatch")
+}
+
+func TestCalcEach(t *testing.T) {
+	results, err := Calc(NewConfig("100", "1")).
+		Cash(CashInput{CashOnHand: "1000", HawlSatisfied: true}).
+		Business(BusinessInput{CashOnHand: "2000", HawlSatisfied: true}).
+		Each()
+	if err != nil {
+		t.Fatalf("each failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	assertDecimalEqual(t, results[0].ZakatDue, "25", "cash zakat_due mismatch")
+	assertDecimalEqual(t, results[1].ZakatDue, "50", "business zakat_due mismatch")
+}
+
+func TestCalcPropagatesErrors(t *testing.T) {
+	_, err := Calc(NewConfig("100", "1")).
+		Gold(GoldInput{WeightGrams: "bad"}).
+		Portfolio()
+	if !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal, got %v", err)
+	}
+}
+
+func TestCalcPortfolioAppliesHawlPolicy(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PortfolioHawlPolicy = HawlStrictest
+	business := BusinessInput{CashOnHand: "10000", HawlSatisfied: true}
+	cash := CashInput{CashOnHand: "5000", HawlSatisfied: false}
+
+	built, err := Calc(config).Business(business).Cash(cash).Portfolio()
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	direct, err := CalculatePortfolio(PortfolioInput{Business: []BusinessInput{business}, Cash: []CashInput{cash}}, config)
+	if err != nil {
+		t.Fatalf("CalculatePortfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, built.ZakatDue, "0", "strictest hawl should hold the whole portfolio")
+	assertDecimalEqual(t, built.ZakatDue, direct.ZakatDue, "builder and CalculatePortfolio disagree")
+	if built.IsPayable != direct.IsPayable {
+		t.Errorf("builder payable %v, CalculatePortfolio payable %v", built.IsPayable, direct.IsPayable)
+	}
+}
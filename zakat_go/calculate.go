@@ -0,0 +1,958 @@
+package zakat
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// goldNisabGrams is the gold nisab: 20 dinars (approx. 85 grams).
+	goldNisabGrams = decimal.NewFromInt(85)
+	// silverNisabGrams is the silver nisab: 200 dirhams (approx. 595 grams).
+	silverNisabGrams = decimal.NewFromInt(595)
+	// tradeGoodsRate is the standard 2.5% rate on monetary wealth.
+	tradeGoodsRate = decimal.RequireFromString("0.025")
+
+	karat24       = decimal.NewFromInt(24)
+	fineness1000  = decimal.NewFromInt(1000)
+	usageInvest   = "Investment"
+	usagePersonal = "PersonalUse"
+)
+
+// parseAmount parses a non-negative decimal field. An empty string is zero.
+func parseAmount(field, s string) (decimal.Decimal, error) {
+	if strings.TrimSpace(s) == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return decimal.Zero, fieldError(ErrInvalidDecimal, field, s)
+	}
+	if d.IsNegative() {
+		return decimal.Zero, fieldError(ErrNegativeValue, field, s)
+	}
+	return d, nil
+}
+
+// Validate checks that the config prices are valid decimals and the madhab,
+// price basis and debt offset scope are known.
+//
+// A config that is valid but implausible, such as a silver price above the
+// gold price or prices older than MaxPriceAge, is still accepted and
+// reported as advisory warnings.
+func (c Config) Validate() ([]Warning, error) {
+	gold, silver, err := c.prices()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.rules(); err != nil {
+		return nil, err
+	}
+	if err := c.DebtOffsetScope.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := parseAmount("personal_exemption", c.PersonalExemption); err != nil {
+		return nil, err
+	}
+	if _, err := c.hawlDuration(); err != nil {
+		return nil, err
+	}
+	if err := c.validateRoundingByAssetType(); err != nil {
+		return nil, err
+	}
+	if err := c.NisabRoundingDirection.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.PortfolioHawlPolicy.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.AgricultureNisabPolicy.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.FutureReceivablePolicy.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.NisabUnit.validate(); err != nil {
+		return nil, err
+	}
+	if err := c.validateFXRates(); err != nil {
+		return nil, err
+	}
+	if err := c.validateIntermediateScale(); err != nil {
+		return nil, err
+	}
+	if _, _, err := c.valuationPrice("gold", gold, c.GoldBuyBackPricePerGram); err != nil {
+		return nil, err
+	}
+	if _, _, err := c.valuationPrice("silver", silver, c.SilverBuyBackPricePerGram); err != nil {
+		return nil, err
+	}
+
+	var warnings []Warning
+	if silver.GreaterThan(gold) && gold.IsPositive() {
+		warnings = append(warnings, Warning{
+			Code:    WarningPricesSwapped,
+			Field:   "silver_price_per_gram",
+			Message: fmt.Sprintf("silver price %s exceeds gold price %s; the prices may be swapped", silver, gold),
+		})
+	}
+	if c.MaxPriceAge > 0 && !c.PricesAt.IsZero() {
+		if age := c.asOf().Sub(c.PricesAt); age > c.MaxPriceAge {
+			warnings = append(warnings, Warning{
+				Code:    WarningStalePrices,
+				Field:   "prices_at",
+				Message: fmt.Sprintf("prices quoted %s were %s old at %s, over the %s limit", c.PricesAt.Format(time.RFC3339), age, c.asOf().Format(time.RFC3339), c.MaxPriceAge),
+			})
+		}
+	}
+	return warnings, nil
+}
+
+// monetaryNisab returns the nisab threshold for cash and trade goods,
+// rounded per Config.NisabRoundingDirection.
+func monetaryNisab(config Config, rules zakatRules) (decimal.Decimal, error) {
+	if err := config.NisabRoundingDirection.validate(); err != nil {
+		return decimal.Zero, err
+	}
+	var nisab decimal.Decimal
+	var err error
+	if config.NisabResolver != nil {
+		nisab, err = resolveNisab(context.Background(), config)
+	} else {
+		nisab, _, _, err = priceNisab(config, rules)
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return config.NisabRoundingDirection.apply(nisab), nil
+}
+
+// priceNisab derives the monetary nisab from the metal prices and the
+// madhab's nisab basis, returning the value, metal and grams used.
+func priceNisab(config Config, rules zakatRules) (nisab decimal.Decimal, metal string, grams decimal.Decimal, err error) {
+	gold, silver, err := config.prices()
+	if err != nil {
+		return
+	}
+	needsGold := rules.nisabBasis != NisabBasisSilver
+	needsSilver := rules.nisabBasis != NisabBasisGold
+	if needsGold && !gold.IsPositive() {
+		err = fieldError(ErrMissingPrice, "gold_price_per_gram", config.GoldPricePerGram)
+		return
+	}
+	if needsSilver && !silver.IsPositive() {
+		err = fieldError(ErrMissingPrice, "silver_price_per_gram", config.SilverPricePerGram)
+		return
+	}
+
+	goldGrams, silverGrams := config.nisabGrams()
+	goldThreshold := gold.Mul(goldGrams)
+	silverThreshold := silver.Mul(silverGrams)
+	useGold := rules.nisabBasis == NisabBasisGold ||
+		(rules.nisabBasis != NisabBasisSilver && goldThreshold.LessThan(silverThreshold))
+	if useGold {
+		return goldThreshold, "gold", goldGrams, nil
+	}
+	return silverThreshold, "silver", silverGrams, nil
+}
+
+// monetaryParams are the inputs to the shared monetary calculation.
+type monetaryParams struct {
+	totalAssets decimal.Decimal
+	liabilities decimal.Decimal
+	// disputed are liabilities whose existence or amount is contested; they
+	// are only deducted under Config.IncludeDisputedLiabilities.
+	disputed      decimal.Decimal
+	nisab         decimal.Decimal
+	rate          decimal.Decimal
+	hawlSatisfied bool
+	// minimumBalance, when set, must also meet the nisab for the result to
+	// be payable (Config.UseMinimumBalance).
+	minimumBalance *decimal.Decimal
+	// nisabMet, when set, replaces the monetary nisab test with a
+	// precomputed one (Config.MetalNisabInGrams).
+	nisabMet *bool
+	// deferredPurchases are committed deferred-purchase or layaway
+	// payments, deducted like short-term liabilities.
+	deferredPurchases decimal.Decimal
+	// taxesDue are taxes assessed and currently payable, deducted like
+	// short-term liabilities.
+	taxesDue decimal.Decimal
+	// priorZakat is last year's zakat still unpaid; deducted as a liability
+	// only under Config.ZakatAsLiability.
+	priorZakat decimal.Decimal
+	// charity is voluntary charity given during the year; reported, and
+	// credited against the due under Config.CharityCountsTowardZakat.
+	charity     decimal.Decimal
+	assetType   string
+	breakdown   []BreakdownLine
+	assumptions []string
+	config      Config
+}
+
+// calculateMonetary performs the standard monetary calculation:
+// hawl check, net assets, nisab check, rate application and breakdown.
+func calculateMonetary(p monetaryParams) (ZakatResult, error) {
+	exemption, err := parseAmount("personal_exemption", p.config.PersonalExemption)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	liabilities := p.liabilities.Add(p.deferredPurchases).Add(p.taxesDue)
+	if p.taxesDue.IsPositive() {
+		p.assumptions = append(p.assumptions, fmt.Sprintf("Taxes of %s currently due deducted; estimated taxes on future income are not.", p.taxesDue))
+	}
+	var priorZakatLine []BreakdownLine
+	if p.priorZakat.IsPositive() {
+		if p.config.ZakatAsLiability {
+			liabilities = liabilities.Add(p.priorZakat)
+			priorZakatLine = append(priorZakatLine, amountLine("step-prior-zakat", "Unpaid Prior Zakat", p.priorZakat, OpSubtract))
+			p.assumptions = append(p.assumptions, fmt.Sprintf("Unpaid zakat of %s from the previous year deducted as a debt (ZakatAsLiability).", p.priorZakat))
+		} else {
+			p.assumptions = append(p.assumptions, fmt.Sprintf("Unpaid zakat of %s from the previous year not deducted; it is still owed separately.", p.priorZakat))
+		}
+	}
+	var disputedLine []BreakdownLine
+	if p.disputed.IsPositive() {
+		if p.config.IncludeDisputedLiabilities {
+			liabilities = liabilities.Add(p.disputed)
+			disputedLine = append(disputedLine, amountLine("step-disputed-liabilities", "Disputed Liabilities", p.disputed, OpSubtract))
+			p.assumptions = append(p.assumptions, fmt.Sprintf("Disputed liabilities of %s deducted (IncludeDisputedLiabilities).", p.disputed))
+		} else {
+			p.assumptions = append(p.assumptions, fmt.Sprintf("Disputed liabilities of %s not deducted; only undisputed debts reduce the zakatable base.", p.disputed))
+		}
+	}
+
+	if !p.hawlSatisfied {
+		return ZakatResult{
+			AssetType:      p.assetType,
+			ZakatDue:       "0",
+			TotalAssets:    "0",
+			NetAssets:      "0",
+			NisabThreshold: p.nisab.String(),
+			Breakdown:      []BreakdownLine{infoLine("status-exempt", "Hawl (1 lunar year) not met")},
+			Assumptions:    p.assumptions,
+			ConfigSnapshot: p.config,
+		}, nil
+	}
+
+	// Liabilities exceeding assets leave nothing zakatable, never a negative base.
+	netAssets := decimal.Max(p.totalAssets.Sub(liabilities), decimal.Zero)
+	metNisab := meetsNisab(netAssets, p.nisab)
+	if p.nisabMet != nil {
+		metNisab = *p.nisabMet && netAssets.IsPositive()
+	}
+	isPayable := metNisab
+	minimumBelowNisab := p.minimumBalance != nil && !meetsNisab(*p.minimumBalance, p.nisab)
+	if minimumBelowNisab {
+		isPayable = false
+	}
+	// The personal exemption reduces the base after the nisab test; it is
+	// not a second nisab.
+	base := netAssets
+	if isPayable && exemption.IsPositive() {
+		base = decimal.Max(netAssets.Sub(exemption), decimal.Zero)
+		isPayable = base.IsPositive()
+		p.assumptions = append(p.assumptions, fmt.Sprintf(personalExemptionNote, exemption))
+	}
+	zakatDue := decimal.Zero
+	if isPayable {
+		zakatDue = base.Mul(p.rate)
+	}
+
+	breakdown := p.breakdown
+	if p.liabilities.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-debts-due-now", "Liabilities", p.liabilities, OpSubtract))
+	}
+	if p.deferredPurchases.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-deferred-purchases", "Deferred Purchase Obligations", p.deferredPurchases, OpSubtract))
+	}
+	if p.taxesDue.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-taxes-due", "Taxes Due", p.taxesDue, OpSubtract))
+	}
+	breakdown = append(breakdown, priorZakatLine...)
+	breakdown = append(breakdown, disputedLine...)
+	breakdown = append(breakdown, amountLine("step-net-assets", "Net Assets", netAssets, OpResult))
+	if p.minimumBalance != nil {
+		breakdown = append(breakdown, amountLine("step-minimum-balance", "Minimum Balance Over Hawl", *p.minimumBalance, OpCompare))
+	}
+	breakdown = append(breakdown, amountLine("step-nisab-check", "Nisab Threshold", p.nisab, OpCompare))
+	if p.charity.IsPositive() && !p.config.CharityCountsTowardZakat {
+		breakdown = append(breakdown, amountLine("step-charity-given", "Charity Given This Year (not deducted)", p.charity, OpInfo))
+		p.assumptions = append(p.assumptions, fmt.Sprintf("Charity of %s given this year reported only; voluntary sadaqah does not reduce zakat.", p.charity))
+	}
+	if p.charity.IsPositive() && p.config.CharityCountsTowardZakat {
+		// Reported whether or not this asset owes anything, so a portfolio
+		// can credit it against the pooled due.
+		breakdown = append(breakdown, amountLine("step-charity-intended", "Zakat-Intended Charity Given This Year", p.charity, OpInfo))
+	}
+	if !base.Equal(netAssets) {
+		breakdown = append(breakdown,
+			amountLine("step-personal-exemption", "Personal Exemption", exemption, OpSubtract),
+			amountLine("step-zakatable-base", "Zakatable Base", base, OpResult),
+		)
+	}
+	switch {
+	case minimumBelowNisab:
+		breakdown = append(breakdown, infoLine("status-exempt", "Minimum balance below Nisab"))
+	case !isPayable && metNisab:
+		breakdown = append(breakdown, infoLine("status-exempt", "Covered by Personal Exemption"))
+	case isPayable:
+		breakdown = append(breakdown, amountLine("step-rate-applied", "Rate Applied", p.rate, OpRate))
+		if p.charity.IsPositive() && p.config.CharityCountsTowardZakat {
+			credited := decimal.Min(p.charity, zakatDue)
+			breakdown = append(breakdown,
+				amountLine("step-zakat-obligation", "Zakat Obligation", zakatDue, OpResult),
+				amountLine("step-charity-credited", "Zakat-Intended Charity Given", credited, OpSubtract),
+			)
+			zakatDue = zakatDue.Sub(credited)
+			p.assumptions = append(p.assumptions, fmt.Sprintf("Charity of %s given with zakat intention credited as advance zakat (CharityCountsTowardZakat).", p.charity))
+		}
+		breakdown = append(breakdown, amountLine("status-due", "Zakat Due", zakatDue, OpResult))
+	default:
+		breakdown = append(breakdown, infoLine("status-exempt", "Below Nisab"))
+	}
+
+	return ZakatResult{
+		AssetType:      p.assetType,
+		IsPayable:      isPayable,
+		ZakatDue:       zakatDue.String(),
+		TotalAssets:    p.totalAssets.String(),
+		NetAssets:      netAssets.String(),
+		NisabThreshold: p.nisab.String(),
+		Breakdown:      breakdown,
+		Assumptions:    p.assumptions,
+		ConfigSnapshot: p.config,
+	}, nil
+}
+
+// personalExemptionNote records Config.PersonalExemption being deducted.
+const personalExemptionNote = "Personal exemption of %s deducted before the rate (PersonalExemption)."
+
+// meetsNisab is the canonical payability test: net assets at or above the
+// nisab are payable. The comparison is exact and inclusive, so a value equal
+// to the nisab is payable and one smallest unit below it is not. It must never
+// use the tolerant DecimalEqual, which exists for test assertions only.
+func meetsNisab(netAssets, nisab decimal.Decimal) bool {
+	return netAssets.IsPositive() && netAssets.Cmp(nisab) >= 0
+}
+
+// exemptResult is returned for holdings that are exempt before valuation.
+func exemptResult(assetType, reason string, assumptions []string, config Config) ZakatResult {
+	return ZakatResult{
+		AssetType:      assetType,
+		ZakatDue:       "0",
+		TotalAssets:    "0",
+		NetAssets:      "0",
+		NisabThreshold: "0",
+		Breakdown:      []BreakdownLine{infoLine("status-exempt", reason)},
+		Assumptions:    assumptions,
+		ConfigSnapshot: config,
+	}
+}
+
+func amountLine(key, label string, amount decimal.Decimal, op Operation) BreakdownLine {
+	return BreakdownLine{Key: key, Label: label, Amount: amount.String(), Op: op}
+}
+
+func quantityLine(key, label string, amount decimal.Decimal, op Operation, unit Unit) BreakdownLine {
+	line := amountLine(key, label, amount, op)
+	line.Unit = unit
+	return line
+}
+
+func infoLine(key, label string) BreakdownLine {
+	return BreakdownLine{Key: key, Label: label, Op: OpInfo}
+}
+
+// businessValues holds the parsed fields of a BusinessInput.
+type businessValues struct {
+	cash, inventory, receivables, liabilities, disputed, reserve, fixed, charity, priorZakat decimal.Decimal
+	// netProfit is signed; a loss is negative.
+	netProfit decimal.Decimal
+	taxes     decimal.Decimal
+	// receivableItems are the amounts of BusinessInput.ReceivableItems.
+	receivableItems []decimal.Decimal
+}
+
+func (in BusinessInput) parse() (v businessValues, err error) {
+	if v.cash, err = parseAmount("cash_on_hand", in.CashOnHand); err != nil {
+		return
+	}
+	if v.inventory, err = parseAmount("inventory_value", in.InventoryValue); err != nil {
+		return
+	}
+	if v.receivables, err = parseAmount("receivables", in.Receivables); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	if v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities); err != nil {
+		return
+	}
+	if v.reserve, err = parseAmount("operating_reserve", in.OperatingReserve); err != nil {
+		return
+	}
+	if v.fixed, err = parseAmount("fixed_assets", in.FixedAssets); err != nil {
+		return
+	}
+	if v.charity, err = parseAmount("charity_given_this_year", in.CharityGivenThisYear); err != nil {
+		return
+	}
+	if v.priorZakat, err = parseAmount("prior_unpaid_zakat", in.PriorUnpaidZakat); err != nil {
+		return
+	}
+	if v.taxes, err = parseAmount("taxes_due", in.TaxesDue); err != nil {
+		return
+	}
+	if v.receivableItems, err = parseReceivables(in.ReceivableItems); err != nil {
+		return
+	}
+	if strings.TrimSpace(in.NetProfit) != "" {
+		if v.netProfit, err = decimal.NewFromString(strings.TrimSpace(in.NetProfit)); err != nil {
+			return v, fieldError(ErrInvalidDecimal, "net_profit", in.NetProfit)
+		}
+	}
+	return
+}
+
+// Validate checks that all business amounts are valid non-negative decimals;
+// only NetProfit may be negative.
+func (in BusinessInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// CalculateBusiness calculates zakat on business assets (urud al-tijarah):
+// (cash + inventory at market value + receivables) - liabilities due now.
+func CalculateBusiness(input BusinessInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if err := config.DebtOffsetScope.validate(); err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	var assumptions []string
+	breakdown := []BreakdownLine{amountLine("step-cash-on-hand", "Cash on Hand", v.cash, OpAdd)}
+	cash := v.cash
+	if v.reserve.IsPositive() {
+		if config.DeductOperatingReserve {
+			excluded := decimal.Min(v.reserve, v.cash)
+			cash = cash.Sub(excluded)
+			breakdown = append(breakdown, amountLine("step-operating-reserve", "Operating Reserve (excluded)", excluded, OpSubtract))
+			assumptions = append(assumptions, fmt.Sprintf("Operating reserve of %s excluded from zakatable cash as a working necessity (DeductOperatingReserve).", excluded))
+		} else {
+			assumptions = append(assumptions, fmt.Sprintf("Operating reserve of %s not deducted; all cash on hand is zakatable.", v.reserve))
+		}
+	}
+	inventory, converted, err := config.toBaseCurrency(v.inventory, input.InventoryCurrency, "inventory_currency")
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if converted {
+		breakdown = append(breakdown, amountLine("step-inventory-foreign", "Inventory Value ("+strings.ToUpper(strings.TrimSpace(input.InventoryCurrency))+")", v.inventory, OpInfo))
+		assumptions = append(assumptions, fmt.Sprintf("Inventory of %s %s converted to %s in the base currency (FXRates).", v.inventory, strings.ToUpper(strings.TrimSpace(input.InventoryCurrency)), inventory))
+	}
+	breakdown = append(breakdown, amountLine("step-inventory-value", "Inventory Value", inventory, OpAdd))
+	if v.receivables.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-receivables", "Receivables", v.receivables, OpAdd))
+	}
+	currentItems, deferredItems, breakdown, err := config.splitReceivables(input.ReceivableItems, v.receivableItems, breakdown)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if deferredItems.IsPositive() {
+		assumptions = append(assumptions, fmt.Sprintf("Receivables of %s due beyond the next hawl deferred until received (FutureReceivableDeferUntilDue).", deferredItems))
+	}
+	gross := cash.Add(inventory).Add(v.receivables).Add(currentItems)
+	breakdown = append(breakdown, amountLine("step-gross-assets", "Gross Assets", gross, OpResult))
+
+	liabilities, disputed, taxes := v.liabilities, v.disputed, v.taxes
+	if v.fixed.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-fixed-assets", "Fixed Assets (not zakatable)", v.fixed, OpInfo))
+		liabilities = config.DebtOffsetScope.deductible(v.liabilities, gross, v.fixed, config.splitPlaces())
+		disputed = config.DebtOffsetScope.deductible(v.disputed, gross, v.fixed, config.splitPlaces())
+		taxes = config.DebtOffsetScope.deductible(v.taxes, gross, v.fixed, config.splitPlaces())
+		if config.DebtOffsetScope == DebtOffsetAllAssets {
+			assumptions = append(assumptions, fmt.Sprintf("Liabilities spread across all assets; %s of %s deducted from zakatable assets (DebtOffsetScope).", liabilities, v.liabilities))
+		}
+	}
+
+	result, err := calculateMonetary(monetaryParams{
+		totalAssets:   gross,
+		liabilities:   liabilities,
+		disputed:      disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		charity:       v.charity,
+		taxesDue:      taxes,
+		priorZakat:    v.priorZakat,
+		assetType:     AssetTypeBusiness,
+		breakdown:     breakdown,
+		assumptions:   assumptions,
+		config:        config,
+	})
+	if err == nil && deferredItems.IsPositive() {
+		result.DeferredAmount = deferredItems.String()
+	}
+	if err == nil && v.netProfit.IsNegative() && result.NetAssetsDecimal().IsPositive() {
+		result.Assumptions = append(result.Assumptions, fmt.Sprintf(
+			"The business reported a net loss of %s, but a loss does not exempt it: zakat is due on net assets of %s when they meet the nisab.",
+			v.netProfit.Abs(), result.NetAssets))
+	}
+	return result, err
+}
+
+// accountValue holds the parsed fields of a CashAccount.
+type accountValue struct {
+	balance, share decimal.Decimal
+}
+
+// owned returns the holder's share of the balance, split from the
+// co-owners' part with splitExact to the minor unit of places decimal places.
+func (a accountValue) owned(places int32) decimal.Decimal {
+	if a.share.Equal(decimal.NewFromInt(1)) {
+		return a.balance
+	}
+	return splitExact(a.balance, []decimal.Decimal{a.share, decimal.NewFromInt(1).Sub(a.share)}, places)[0]
+}
+
+func (a CashAccount) parse(field string) (v accountValue, err error) {
+	if v.balance, err = parseAmount(field, a.Balance); err != nil {
+		return
+	}
+	v.share = decimal.NewFromInt(1)
+	if strings.TrimSpace(a.OwnershipFraction) != "" {
+		if v.share, err = parseFraction(field+".ownership_fraction", a.OwnershipFraction); err != nil {
+			return
+		}
+		if v.share.GreaterThan(decimal.NewFromInt(1)) {
+			return v, fieldError(ErrInvalidFraction, field+".ownership_fraction", a.OwnershipFraction)
+		}
+	}
+	return
+}
+
+// cashValues holds the parsed fields of a CashInput.
+type cashValues struct {
+	cash, salary, liabilities, disputed, charity, deferred, priorZakat decimal.Decimal
+	accounts, wallets                                                  []accountValue
+	// businessShare is the fraction of the cash held for the business.
+	businessShare decimal.Decimal
+	taxes         decimal.Decimal
+	amanah        decimal.Decimal
+}
+
+func (in CashInput) parse() (v cashValues, err error) {
+	if v.cash, err = parseAmount("cash_on_hand", in.CashOnHand); err != nil {
+		return
+	}
+	for _, account := range in.BankAccounts {
+		value, err := account.parse("bank_accounts." + account.Name)
+		if err != nil {
+			return v, err
+		}
+		v.accounts = append(v.accounts, value)
+	}
+	for _, wallet := range in.EWalletBalances {
+		value, err := wallet.parse("e_wallet_balances." + wallet.Name)
+		if err != nil {
+			return v, err
+		}
+		v.wallets = append(v.wallets, value)
+	}
+	if v.salary, err = parseAmount("accrued_salary", in.AccruedSalary); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	if v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities); err != nil {
+		return
+	}
+	if v.charity, err = parseAmount("charity_given_this_year", in.CharityGivenThisYear); err != nil {
+		return
+	}
+	if v.deferred, err = parseAmount("deferred_purchase_obligations", in.DeferredPurchaseObligations); err != nil {
+		return
+	}
+	if v.priorZakat, err = parseAmount("prior_unpaid_zakat", in.PriorUnpaidZakat); err != nil {
+		return
+	}
+	if v.taxes, err = parseAmount("taxes_due", in.TaxesDue); err != nil {
+		return
+	}
+	if v.amanah, err = parseAmount("amanah_held", in.AmanahHeld); err != nil {
+		return
+	}
+	if v.businessShare, err = parseFraction("business_cash_fraction", in.BusinessCashFraction); err != nil {
+		return
+	}
+	if v.businessShare.GreaterThan(decimal.NewFromInt(1)) {
+		return v, fieldError(ErrInvalidFraction, "business_cash_fraction", in.BusinessCashFraction)
+	}
+	for i, balance := range in.DailyBalances {
+		if _, err = parseAmount(fmt.Sprintf("daily_balances[%d]", i), balance); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Validate checks that cash on hand, account and daily balances, accrued
+// salary and liabilities are valid non-negative decimals, and that
+// BusinessCashFraction is at most 1.
+func (in CashInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// CalculateCash calculates zakat on cash on hand and bank balances against
+// the monetary nisab.
+func CalculateCash(input CashInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	breakdown := []BreakdownLine{amountLine("step-cash-on-hand", "Cash on Hand", v.cash, OpAdd)}
+	total := v.cash
+	var assumptions []string
+	for i, account := range input.BankAccounts {
+		breakdown, assumptions = accountLines(breakdown, assumptions, "step-bank-account", "Bank: "+account.Name, v.accounts[i], config.splitPlaces())
+		total = total.Add(v.accounts[i].owned(config.splitPlaces()))
+	}
+	for i, wallet := range input.EWalletBalances {
+		breakdown, assumptions = accountLines(breakdown, assumptions, "step-e-wallet", "E-Wallet: "+wallet.Name, v.wallets[i], config.splitPlaces())
+		total = total.Add(v.wallets[i].owned(config.splitPlaces()))
+	}
+	if v.salary.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-accrued-salary", "Accrued Salary (receivable)", v.salary, OpAdd))
+		total = total.Add(v.salary)
+	}
+	if v.amanah.IsPositive() {
+		excluded := decimal.Min(v.amanah, total)
+		total = total.Sub(excluded)
+		breakdown = append(breakdown, amountLine("step-amanah-excluded", "Held in Trust for Others (excluded)", excluded, OpSubtract))
+		assumptions = append(assumptions, fmt.Sprintf("Amanah of %s held for others excluded: it is not the holder's wealth.", excluded))
+	}
+	breakdown = append(breakdown, amountLine("step-total-cash", "Total Cash", total, OpResult))
+	if v.businessShare.IsPositive() {
+		shares := splitExact(total, []decimal.Decimal{v.businessShare, decimal.NewFromInt(1).Sub(v.businessShare)}, config.splitPlaces())
+		breakdown = append(breakdown,
+			amountLine("step-business-cash", "Business Share", shares[0], OpInfo),
+			amountLine("step-personal-cash", "Personal Share", shares[1], OpInfo),
+		)
+	}
+
+	var minimum *decimal.Decimal
+	if config.UseMinimumBalance {
+		if len(input.DailyBalances) > 0 {
+			m, err := minBalance(input.DailyBalances)
+			if err != nil {
+				return ZakatResult{}, err
+			}
+			minimum = &m
+			assumptions = append(assumptions, "Payable only if the minimum balance over the hawl meets the nisab (UseMinimumBalance).")
+		} else {
+			assumptions = append(assumptions, "UseMinimumBalance set but no daily balances given; payability uses the year-end balance.")
+		}
+	}
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:       total,
+		liabilities:       v.liabilities,
+		disputed:          v.disputed,
+		nisab:             nisab,
+		rate:              rules.tradeGoodsRate,
+		hawlSatisfied:     input.HawlSatisfied,
+		minimumBalance:    minimum,
+		charity:           v.charity,
+		deferredPurchases: v.deferred,
+		taxesDue:          v.taxes,
+		priorZakat:        v.priorZakat,
+		assetType:         AssetTypeCash,
+		breakdown:         breakdown,
+		assumptions:       assumptions,
+		config:            config,
+	})
+}
+
+// accountLines appends the breakdown line of one account, preceded by its
+// full balance and noted in the assumptions when only a share is owned.
+func accountLines(breakdown []BreakdownLine, assumptions []string, key, label string, account accountValue, places int32) ([]BreakdownLine, []string) {
+	if account.share.LessThan(decimal.NewFromInt(1)) {
+		breakdown = append(breakdown, amountLine("step-joint-balance", label+" (joint balance)", account.balance, OpInfo))
+		assumptions = append(assumptions, fmt.Sprintf("%s is jointly held: only the %s share of %s is counted (OwnershipFraction).", label, account.share, account.balance))
+	}
+	return append(breakdown, amountLine(key, label, account.owned(places), OpAdd)), assumptions
+}
+
+// validateIntermediateScale rejects a negative Config.IntermediateScale.
+func (c Config) validateIntermediateScale() error {
+	if c.IntermediateScale != nil && *c.IntermediateScale < 0 {
+		return fieldError(ErrInvalidOption, "intermediate_scale", strconv.Itoa(*c.IntermediateScale))
+	}
+	return nil
+}
+
+// roundIntermediate rounds an intermediate metal product to
+// Config.IntermediateScale places, or keeps it exact when the scale is unset.
+func (c Config) roundIntermediate(d decimal.Decimal) decimal.Decimal {
+	if c.IntermediateScale == nil {
+		return d
+	}
+	return d.Round(int32(*c.IntermediateScale))
+}
+
+// metalFields are the raw fields shared by GoldInput and SilverInput.
+type metalFields struct {
+	weight, purity, usage, investmentFraction, ownershipFraction, liabilities, disputed string
+}
+
+// metalValues holds the parsed fields of a GoldInput or SilverInput.
+type metalValues struct {
+	weight, purity, liabilities, disputed decimal.Decimal
+	// investmentShare is the fraction of the holding held as investment;
+	// the remainder is personal-use jewelry.
+	investmentShare decimal.Decimal
+	// ownership is the user's share of the holding, 1 for a sole owner.
+	ownership decimal.Decimal
+}
+
+// parseMetal parses the shared metal fields. maxPurity is 24 (karat) for gold
+// and 1000 (millesimal fineness) for silver; an empty purity means pure metal.
+// Purity must be strictly positive: an explicit "0" is rejected, while small
+// valid purities such as 0.5 karat gold dust are accepted.
+//
+// The investment share comes from InvestmentFraction when set, otherwise from
+// Usage. A fraction that contradicts Usage ("Investment" with "0", or
+// "PersonalUse" with "1") is rejected as a likely form-logic bug.
+func parseMetal(f metalFields, maxPurity decimal.Decimal) (v metalValues, err error) {
+	if v.weight, err = parseAmount("weight_grams", f.weight); err != nil {
+		return
+	}
+	if strings.TrimSpace(f.purity) == "" {
+		v.purity = maxPurity
+	} else if v.purity, err = decimal.NewFromString(strings.TrimSpace(f.purity)); err != nil {
+		return v, fieldError(ErrInvalidDecimal, "purity", f.purity)
+	}
+	if !v.purity.IsPositive() || v.purity.GreaterThan(maxPurity) {
+		return v, fieldError(ErrInvalidPurity, "purity", f.purity)
+	}
+
+	var personalUse bool
+	switch f.usage {
+	case "", usageInvest:
+	case usagePersonal:
+		personalUse = true
+	default:
+		return v, fieldError(ErrInvalidUsage, "usage", f.usage)
+	}
+	if strings.TrimSpace(f.investmentFraction) == "" {
+		v.investmentShare = decimal.NewFromInt(1)
+		if personalUse {
+			v.investmentShare = decimal.Zero
+		}
+	} else {
+		if v.investmentShare, err = parseFraction("investment_fraction", f.investmentFraction); err != nil {
+			return
+		}
+		if v.investmentShare.GreaterThan(decimal.NewFromInt(1)) {
+			return v, fieldError(ErrInvalidFraction, "investment_fraction", f.investmentFraction)
+		}
+		if !personalUse && v.investmentShare.IsZero() {
+			return v, fmt.Errorf("%w: usage %q contradicts investment_fraction=%q (nothing held as investment)",
+				ErrInconsistentInput, usageInvest, f.investmentFraction)
+		}
+		if personalUse && v.investmentShare.Equal(decimal.NewFromInt(1)) {
+			return v, fmt.Errorf("%w: usage %q contradicts investment_fraction=%q (everything held as investment)",
+				ErrInconsistentInput, usagePersonal, f.investmentFraction)
+		}
+	}
+	v.ownership = decimal.NewFromInt(1)
+	if strings.TrimSpace(f.ownershipFraction) != "" {
+		if v.ownership, err = parseFraction("ownership_fraction", f.ownershipFraction); err != nil {
+			return
+		}
+		if v.ownership.GreaterThan(decimal.NewFromInt(1)) {
+			return v, fieldError(ErrInvalidFraction, "ownership_fraction", f.ownershipFraction)
+		}
+	}
+	if v.liabilities, err = parseAmount("liabilities", f.liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", f.disputed)
+	return
+}
+
+func (in GoldInput) parse() (metalValues, error) {
+	return parseMetal(metalFields{in.WeightGrams, in.Purity, in.Usage, in.InvestmentFraction, in.OwnershipFraction, in.Liabilities, in.DisputedLiabilities}, karat24)
+}
+
+func (in SilverInput) parse() (metalValues, error) {
+	return parseMetal(metalFields{in.WeightGrams, in.Purity, in.Usage, in.InvestmentFraction, in.OwnershipFraction, in.Liabilities, in.DisputedLiabilities}, fineness1000)
+}
+
+// Validate checks the gold weight, karat purity (above 0, up to 24), usage,
+// and that InvestmentFraction is consistent with Usage.
+func (in GoldInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// Validate checks the silver weight, millesimal fineness (above 0, up to
+// 1000), usage, and that InvestmentFraction is consistent with Usage.
+func (in SilverInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// CalculateGold calculates zakat on gold, valued on its 24K-equivalent weight.
+// The nisab is 85 grams of pure gold at the configured gold price.
+func CalculateGold(input GoldInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	market, _, err := config.prices()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	gold, note, err := config.valuationPrice("gold", market, config.GoldBuyBackPricePerGram)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if !gold.IsPositive() {
+		return ZakatResult{}, fieldError(ErrMissingPrice, "gold_price_per_gram", config.GoldPricePerGram)
+	}
+	nisabGrams, _ := config.nisabGrams()
+	return calculateMetal(v, gold, nisabGrams, karat24, AssetTypeGold, input.HawlSatisfied, note, config)
+}
+
+// CalculateSilver calculates zakat on silver, valued on its pure-silver weight.
+// The nisab is 595 grams of pure silver at the configured silver price.
+func CalculateSilver(input SilverInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	_, market, err := config.prices()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	silver, note, err := config.valuationPrice("silver", market, config.SilverBuyBackPricePerGram)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if !silver.IsPositive() {
+		return ZakatResult{}, fieldError(ErrMissingPrice, "silver_price_per_gram", config.SilverPricePerGram)
+	}
+	_, nisabGrams := config.nisabGrams()
+	return calculateMetal(v, silver, nisabGrams, fineness1000, AssetTypeSilver, input.HawlSatisfied, note, config)
+}
+
+// calculateMetal applies the jewelry exemption and purity normalization, then
+// delegates to the shared monetary calculation. priceNote, if set, records
+// how the price per gram was chosen.
+func calculateMetal(v metalValues, price, nisabGrams, maxPurity decimal.Decimal, assetType string, hawl bool, priceNote string, config Config) (ZakatResult, error) {
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if err := config.validateIntermediateScale(); err != nil {
+		return ZakatResult{}, err
+	}
+	if v.investmentShare.IsZero() && rules.jewelryExempt {
+		return exemptResult(assetType, "Exempt per Madhab (Huliyy al-Mubah)",
+			[]string{"Personal-use jewelry is exempt under the configured madhab."}, config), nil
+	}
+
+	breakdown := []BreakdownLine{
+		quantityLine("step-weight", "Total Weight (grams)", v.weight, OpAdd, UnitGrams),
+		amountLine("step-price-per-gram", "Price per gram", price, OpInfo),
+	}
+	var assumptions []string
+	if priceNote != "" {
+		assumptions = append(assumptions, priceNote)
+	}
+	weight := v.weight
+	if v.ownership.LessThan(decimal.NewFromInt(1)) {
+		weight = v.weight.Mul(v.ownership)
+		breakdown = append(breakdown, quantityLine("step-owned-weight", "Owned Share (grams)", weight, OpResult, UnitGrams))
+		assumptions = append(assumptions, fmt.Sprintf("Jointly owned: only the %s share of the weight is valued (OwnershipFraction).", v.ownership))
+	}
+	pureWeight := weight
+	if v.purity.LessThan(maxPurity) {
+		// Multiply before dividing so exact purities (18K, 925) stay exact.
+		pureWeight = config.roundIntermediate(weight.Mul(v.purity).Div(maxPurity))
+		breakdown = append(breakdown, quantityLine("step-effective-weight", "Effective Pure Weight", pureWeight, OpResult, UnitGrams))
+	}
+	if rules.jewelryExempt && v.investmentShare.LessThan(decimal.NewFromInt(1)) {
+		invested := pureWeight.Mul(v.investmentShare)
+		exempt := pureWeight.Sub(invested)
+		pureWeight = invested
+		breakdown = append(breakdown, quantityLine("step-personal-use-exempt", "Personal-Use Share (exempt)", exempt, OpSubtract, UnitGrams))
+		assumptions = append(assumptions, fmt.Sprintf("Personal-use share of %s grams is exempt under the configured madhab.", exempt))
+	}
+	totalValue := config.roundIntermediate(pureWeight.Mul(price))
+	breakdown = append(breakdown, amountLine("step-total-value", "Total Value", totalValue, OpResult))
+
+	nisabMet, breakdown, assumptions := metalNisab(pureWeight, nisabGrams, breakdown, assumptions, config)
+	return calculateMonetary(monetaryParams{
+		totalAssets:   totalValue,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisabGrams.Mul(price),
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: hawl,
+		nisabMet:      nisabMet,
+		assetType:     assetType,
+		breakdown:     breakdown,
+		assumptions:   assumptions,
+		config:        config,
+	})
+}
+
+// metalNisab appends the metal nisab lines for pureWeight grams held,
+// sharing them between weighed and appraised metal. Under
+// Config.MetalNisabInGrams it also returns the nisab decision, taken on the
+// weight independent of price; otherwise nil leaves it to the price.
+func metalNisab(pureWeight, nisabGrams decimal.Decimal, breakdown []BreakdownLine, assumptions []string, config Config) (*bool, []BreakdownLine, []string) {
+	if config.NisabUnit == NisabUnitTola {
+		breakdown = append(breakdown, quantityLine("step-nisab-tola", "Nisab (tola)", toTola(nisabGrams), OpInfo, UnitTola))
+		assumptions = append(assumptions, fmt.Sprintf("Nisab of %s tola (%s grams) at %s grams per tola (NisabUnitTola).", toTola(nisabGrams), nisabGrams, gramsPerTola))
+	}
+	if !config.MetalNisabInGrams {
+		return nil, breakdown, assumptions
+	}
+	met := pureWeight.Cmp(nisabGrams) >= 0
+	breakdown = append(breakdown, quantityLine("step-nisab-grams", "Nisab (grams)", nisabGrams, OpCompare, UnitGrams))
+	assumptions = append(assumptions, fmt.Sprintf("Nisab tested on %s grams of pure metal held against %s grams, independent of price (MetalNisabInGrams).", pureWeight, nisabGrams))
+	return &met, breakdown, assumptions
+}
@@ -0,0 +1,889 @@
+package zakat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCalculateBusinessAboveNisab(t *testing.T) {
+	config := NewConfig("100", "1")
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:     "5000",
+		InventoryValue: "3000",
+		Receivables:    "2000",
+		HawlSatisfied:  true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("expected payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+	assertDecimalEqual(t, result.NetAssets, "10000", "net_assets mismatch")
+	assertDecimalEqual(t, result.NisabThreshold, "595", "nisab mismatch")
+}
+
+func TestCalculateBusinessHawlNotMet(t *testing.T) {
+	result, err := CalculateBusiness(BusinessInput{CashOnHand: "100000"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("expected not payable without hawl")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+}
+
+func TestCalculateBusinessLiabilitiesExceedAssets(t *testing.T) {
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:    "5000",
+		Liabilities:   "10000",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("expected not payable")
+	}
+	assertDecimalEqual(t, result.NetAssets, "0", "net_assets should be clamped to zero")
+}
+
+func TestCalculateBusinessLossStillZakatable(t *testing.T) {
+	config := NewConfig("100", "1")
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:     "20000",
+		InventoryValue: "5000",
+		Liabilities:    "5000",
+		NetProfit:      "-3000",
+		HawlSatisfied:  true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "500", "a loss should not reduce the zakat on net assets")
+	if len(result.Assumptions) != 1 || !strings.Contains(result.Assumptions[0], "net loss of 3000") {
+		t.Errorf("expected a note that the loss does not exempt, got %v", result.Assumptions)
+	}
+
+	// No note once losses have left nothing zakatable.
+	result, err = CalculateBusiness(BusinessInput{CashOnHand: "1000", Liabilities: "2000", NetProfit: "-3000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if len(result.Assumptions) != 0 {
+		t.Errorf("expected no note without net assets, got %v", result.Assumptions)
+	}
+
+	if err := (BusinessInput{NetProfit: "loss"}).Validate(); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal, got %v", err)
+	}
+}
+
+func TestCalculateBusinessMissingPrice(t *testing.T) {
+	_, err := CalculateBusiness(BusinessInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("0", "1"))
+	if !errors.Is(err, ErrMissingPrice) {
+		t.Errorf("expected ErrMissingPrice, got %v", err)
+	}
+}
+
+func TestCalculateBusinessInvalidInput(t *testing.T) {
+	_, err := CalculateBusiness(BusinessInput{CashOnHand: "abc"}, NewConfig("100", "1"))
+	if !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal, got %v", err)
+	}
+	_, err = CalculateBusiness(BusinessInput{CashOnHand: "-1"}, NewConfig("100", "1"))
+	if !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestCalculateBusinessOperatingReserveNotApplied(t *testing.T) {
+	input := BusinessInput{
+		CashOnHand:       "10000",
+		OperatingReserve: "4000",
+		HawlSatisfied:    true,
+	}
+	result, err := CalculateBusiness(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "reserve must not be deducted by default")
+	if len(result.Assumptions) != 1 || !strings.Contains(result.Assumptions[0], "not deducted") {
+		t.Errorf("expected a not-deducted assumption, got %v", result.Assumptions)
+	}
+	for _, line := range result.Breakdown {
+		if line.Key == "step-operating-reserve" {
+			t.Errorf("unexpected reserve breakdown line: %+v", line)
+		}
+	}
+}
+
+func TestCalculateBusinessOperatingReserveApplied(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.DeductOperatingReserve = true
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:       "10000",
+		InventoryValue:   "2000",
+		OperatingReserve: "4000",
+		HawlSatisfied:    true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "8000", "net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "200", "zakat_due mismatch")
+
+	found := false
+	for _, line := range result.Breakdown {
+		if line.Key == "step-operating-reserve" {
+			found = true
+			assertDecimalEqual(t, line.Amount, "4000", "reserve line mismatch")
+			if line.Op != OpSubtract {
+				t.Errorf("reserve line should subtract, got %s", line.Op)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("missing reserve breakdown line")
+	}
+	if len(result.Assumptions) != 1 || !strings.Contains(result.Assumptions[0], "excluded") {
+		t.Errorf("expected an excluded assumption, got %v", result.Assumptions)
+	}
+}
+
+func TestCalculateBusinessOperatingReserveCappedAtCash(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.DeductOperatingReserve = true
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:       "1000",
+		InventoryValue:   "9000",
+		OperatingReserve: "5000",
+		HawlSatisfied:    true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "9000", "reserve must not reduce non-cash assets")
+}
+
+func TestCalculateGold(t *testing.T) {
+	config := NewConfig("100", "1")
+	tests := []struct {
+		name     string
+		input    GoldInput
+		payable  bool
+		due      string
+		netAsset string
+	}{
+		{"above nisab", GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}, true, "250", "10000"},
+		{"below nisab", GoldInput{WeightGrams: "80", Purity: "24", HawlSatisfied: true}, false, "0", "8000"},
+		{"18K purity", GoldInput{WeightGrams: "100", Purity: "18", HawlSatisfied: true}, false, "0", "7500"},
+		{"liabilities", GoldInput{WeightGrams: "100", Purity: "24", Liabilities: "2000", HawlSatisfied: true}, false, "0", "8000"},
+		{"at nisab", GoldInput{WeightGrams: "85", Purity: "24", HawlSatisfied: true}, true, "212.5", "8500"},
+		{"hanafi personal use", GoldInput{WeightGrams: "100", Purity: "24", Usage: "PersonalUse", HawlSatisfied: true}, true, "250", "10000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateGold(tt.input, config)
+			if err != nil {
+				t.Fatalf("calculation failed: %v", err)
+			}
+			if result.IsPayable != tt.payable {
+				t.Errorf("is_payable mismatch: got %v, want %v", result.IsPayable, tt.payable)
+			}
+			assertDecimalEqual(t, result.ZakatDue, tt.due, "zakat_due mismatch")
+			assertDecimalEqual(t, result.NetAssets, tt.netAsset, "net_assets mismatch")
+		})
+	}
+}
+
+func TestCalculateGoldPersonalUseExemptShafi(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := CalculateGold(GoldInput{WeightGrams: "100", Purity: "24", Usage: "PersonalUse", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("personal jewelry should be exempt under Shafi")
+	}
+}
+
+func TestCalculateSilver(t *testing.T) {
+	config := NewConfig("100", "1")
+	result, err := CalculateSilver(SilverInput{WeightGrams: "650", Purity: "925", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("expected payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "15.03125", "zakat_due mismatch")
+	assertDecimalEqual(t, result.NetAssets, "601.25", "net_assets mismatch")
+}
+
+func TestCalculateGoldInvalidUsage(t *testing.T) {
+	_, err := CalculateGold(GoldInput{WeightGrams: "100", Usage: "Decorative"}, NewConfig("100", "1"))
+	if !errors.Is(err, ErrInvalidUsage) {
+		t.Errorf("expected ErrInvalidUsage, got %v", err)
+	}
+}
+
+func TestConfigValidateInvalidMadhab(t *testing.T) {
+	_, err := NewConfig("100", "1").WithMadhab("unknown").Validate()
+	if !errors.Is(err, ErrInvalidMadhab) {
+		t.Errorf("expected ErrInvalidMadhab, got %v", err)
+	}
+}
+
+func TestCalculateCash(t *testing.T) {
+	result, err := CalculateCash(CashInput{
+		CashOnHand:    "1000",
+		BankAccounts:  []CashAccount{{Name: "Savings", Balance: "8000"}, {Name: "Current", Balance: "1000"}},
+		Liabilities:   "2000",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "total_assets mismatch")
+	assertDecimalEqual(t, result.NetAssets, "8000", "net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "200", "zakat_due mismatch")
+	if result.AssetType != AssetTypeCash {
+		t.Errorf("asset type mismatch: %s", result.AssetType)
+	}
+}
+
+func TestCashEWalletBalances(t *testing.T) {
+	config := NewConfig("100", "1")
+	wallets, err := CalculateCash(CashInput{
+		CashOnHand:      "1000",
+		EWalletBalances: []CashAccount{{Name: "GoPay", Balance: "3000"}, {Name: "OVO", Balance: "1000"}},
+		HawlSatisfied:   true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, wallets.TotalAssets, "5000", "e-wallets should count toward total cash")
+	assertDecimalEqual(t, wallets.ZakatDue, "125", "zakat_due mismatch")
+
+	bank, err := CalculateCash(CashInput{
+		CashOnHand:    "1000",
+		BankAccounts:  []CashAccount{{Name: "Savings", Balance: "4000"}},
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if wallets.ZakatDue != bank.ZakatDue {
+		t.Errorf("e-wallet cash owes %s, the same bank cash owes %s", wallets.ZakatDue, bank.ZakatDue)
+	}
+
+	found := false
+	for _, line := range wallets.Breakdown {
+		found = found || line.Label == "E-Wallet: GoPay"
+	}
+	if !found {
+		t.Error("breakdown should list each e-wallet")
+	}
+
+	_, err = CalculateCash(CashInput{EWalletBalances: []CashAccount{{Name: "OVO", Balance: "-5"}}}, config)
+	if !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestCashBusinessCashFraction(t *testing.T) {
+	config := NewConfig("100", "1")
+	input := CashInput{CashOnHand: "2000", BankAccounts: []CashAccount{{Name: "Current", Balance: "8000"}}, HawlSatisfied: true}
+	personal, err := CalculateCash(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	input.BusinessCashFraction = "30%"
+	mixed, err := CalculateCash(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if mixed.ZakatDue != personal.ZakatDue {
+		t.Errorf("the split should not change the due: %s vs %s", mixed.ZakatDue, personal.ZakatDue)
+	}
+
+	shares := map[string]string{}
+	for _, line := range mixed.Breakdown {
+		shares[line.Key] = line.Amount
+	}
+	assertDecimalEqual(t, shares["step-business-cash"], "3000", "business share mismatch")
+	assertDecimalEqual(t, shares["step-personal-cash"], "7000", "personal share mismatch")
+	for _, line := range personal.Breakdown {
+		if line.Key == "step-business-cash" {
+			t.Error("all-personal cash should not show a business share")
+		}
+	}
+
+	input.BusinessCashFraction = "150%"
+	if err := input.Validate(); !errors.Is(err, ErrInvalidFraction) {
+		t.Errorf("expected ErrInvalidFraction, got %v", err)
+	}
+}
+
+func TestTaxesDueReduceZakatableBase(t *testing.T) {
+	config := NewConfig("100", "1")
+	business, err := CalculateBusiness(BusinessInput{
+		CashOnHand:     "20000",
+		InventoryValue: "10000",
+		Liabilities:    "2000",
+		TaxesDue:       "4000",
+		HawlSatisfied:  true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, business.NetAssets, "24000", "taxes due should reduce the base")
+	assertDecimalEqual(t, business.ZakatDue, "600", "zakat_due mismatch")
+
+	found := false
+	for _, line := range business.Breakdown {
+		if line.Key == "step-taxes-due" {
+			found = true
+			assertDecimalEqual(t, line.Amount, "4000", "taxes line mismatch")
+		}
+	}
+	if !found {
+		t.Error("taxes due should have their own breakdown line")
+	}
+
+	cash, err := CalculateCash(CashInput{CashOnHand: "10000", TaxesDue: "2000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, cash.ZakatDue, "200", "personal taxes due should reduce the base")
+
+	if err := (CashInput{TaxesDue: "-1"}).Validate(); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestCashJointAccountOwnership(t *testing.T) {
+	config := NewConfig("100", "1")
+	result, err := CalculateCash(CashInput{
+		CashOnHand: "1000",
+		BankAccounts: []CashAccount{
+			{Name: "Own", Balance: "3000"},
+			{Name: "Joint", Balance: "8000", OwnershipFraction: "50%"},
+		},
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "8000", "only half of the joint account should count")
+	assertDecimalEqual(t, result.ZakatDue, "200", "zakat_due mismatch")
+
+	for _, line := range result.Breakdown {
+		if line.Label == "Bank: Joint" {
+			assertDecimalEqual(t, line.Amount, "4000", "joint account line should show the owned share")
+		}
+	}
+
+	_, err = CalculateCash(CashInput{BankAccounts: []CashAccount{{Name: "Joint", Balance: "10", OwnershipFraction: "150%"}}}, config)
+	if !errors.Is(err, ErrInvalidFraction) {
+		t.Errorf("expected ErrInvalidFraction, got %v", err)
+	}
+}
+
+func TestCashJointAccountShareInMinorUnits(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.BaseCurrency = "JPY"
+	result, err := CalculateCash(CashInput{
+		BankAccounts:  []CashAccount{{Name: "Joint", Balance: "1000", OwnershipFraction: "0.3333"}},
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "333", "a yen share should be whole yen")
+
+	config.BaseCurrency = "KWD"
+	result, err = CalculateCash(CashInput{
+		BankAccounts:  []CashAccount{{Name: "Joint", Balance: "10", OwnershipFraction: "0.33337"}},
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "3.334", "a dinar share should be whole fils")
+}
+
+func TestCashAmanahHeldExcluded(t *testing.T) {
+	config := NewConfig("100", "1")
+	own, err := CalculateCash(CashInput{CashOnHand: "2000", BankAccounts: []CashAccount{{Name: "Main", Balance: "8000"}}, HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	trust, err := CalculateCash(CashInput{
+		CashOnHand:    "2000",
+		BankAccounts:  []CashAccount{{Name: "Main", Balance: "13000"}},
+		AmanahHeld:    "5000",
+		HawlSatisfied: true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if trust.ZakatDue != own.ZakatDue {
+		t.Errorf("amanah funds should not add to the due: %s vs %s", trust.ZakatDue, own.ZakatDue)
+	}
+	assertDecimalEqual(t, trust.TotalAssets, "10000", "amanah should be excluded from total cash")
+
+	found := false
+	for _, line := range trust.Breakdown {
+		found = found || (line.Key == "step-amanah-excluded" && line.Op == OpSubtract)
+	}
+	if !found {
+		t.Error("amanah should appear as an excluded line")
+	}
+
+	// Held funds beyond the balances cannot make the base negative.
+	over, err := CalculateCash(CashInput{CashOnHand: "1000", AmanahHeld: "5000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, over.TotalAssets, "0", "exclusion should be capped at the balances")
+}
+
+func TestGoldPurityBounds(t *testing.T) {
+	config := NewConfig("100", "1")
+	for _, purity := range []string{"0", "-1", "24.5"} {
+		_, err := CalculateGold(GoldInput{WeightGrams: "100", Purity: purity, HawlSatisfied: true}, config)
+		if !errors.Is(err, ErrInvalidPurity) {
+			t.Errorf("purity %s: expected ErrInvalidPurity, got %v", purity, err)
+		}
+	}
+
+	// 0.5 karat dust: 4800g * 0.5 / 24 = 100g pure, worth 10000.
+	result, err := CalculateGold(GoldInput{WeightGrams: "4800", Purity: "0.5", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("0.5 karat failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "10000", "0.5 karat net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "250", "0.5 karat zakat_due mismatch")
+
+	// Non-terminating ratio must not collapse to zero.
+	result, err = CalculateGold(GoldInput{WeightGrams: "1", Purity: "0.5", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("0.5 karat failed: %v", err)
+	}
+	if !result.NetAssetsDecimal().IsPositive() {
+		t.Errorf("tiny purity collapsed to zero: %s", result.NetAssets)
+	}
+	assertDecimalEqual(t, result.NetAssets, "2.0833333", "tiny purity precision mismatch")
+
+	result, err = CalculateGold(GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("24 karat failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "10000", "24 karat net_assets mismatch")
+}
+
+func TestSilverPurityBounds(t *testing.T) {
+	config := NewConfig("100", "1")
+	_, err := CalculateSilver(SilverInput{WeightGrams: "600", Purity: "0", HawlSatisfied: true}, config)
+	if !errors.Is(err, ErrInvalidPurity) {
+		t.Errorf("expected ErrInvalidPurity, got %v", err)
+	}
+
+	// 1/1000 fineness: 600000g holds 600g pure silver.
+	result, err := CalculateSilver(SilverInput{WeightGrams: "600000", Purity: "1", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("1 fineness failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "600", "1 fineness net_assets mismatch")
+	if !result.IsPayable {
+		t.Errorf("600g pure silver should be payable")
+	}
+
+	result, err = CalculateSilver(SilverInput{WeightGrams: "600", Purity: "1000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("1000 fineness failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "600", "1000 fineness net_assets mismatch")
+}
+
+func TestNisabBoundaryIsInclusiveAndExact(t *testing.T) {
+	// Shafi uses the gold nisab: 85g * 100 = 8500.
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	tests := []struct {
+		cash    string
+		payable bool
+	}{
+		{"8500", true},
+		{"8500.00", true},
+		{"8499.99", false},
+		{"8500.01", true},
+		// Within DecimalEqual's default tolerance, but still below nisab.
+		{"8499.99999999", false},
+	}
+	for _, tt := range tests {
+		result, err := CalculateCash(CashInput{CashOnHand: tt.cash, HawlSatisfied: true}, config)
+		if err != nil {
+			t.Fatalf("cash %s: calculation failed: %v", tt.cash, err)
+		}
+		if result.IsPayable != tt.payable {
+			t.Errorf("cash %s: is_payable got %v, want %v", tt.cash, result.IsPayable, tt.payable)
+		}
+	}
+}
+
+func TestMetalUsageFractionConsistency(t *testing.T) {
+	invalid := []GoldInput{
+		{WeightGrams: "100", Usage: "Investment", InvestmentFraction: "0"},
+		{WeightGrams: "100", Usage: "PersonalUse", InvestmentFraction: "1"},
+		{WeightGrams: "100", InvestmentFraction: "0"},
+	}
+	for _, in := range invalid {
+		if err := in.Validate(); !errors.Is(err, ErrInconsistentInput) {
+			t.Errorf("%+v: expected ErrInconsistentInput, got %v", in, err)
+		}
+	}
+	if err := (GoldInput{WeightGrams: "100", InvestmentFraction: "1.5"}).Validate(); !errors.Is(err, ErrInvalidFraction) {
+		t.Errorf("expected ErrInvalidFraction, got %v", err)
+	}
+	if err := (GoldInput{WeightGrams: "100", InvestmentFraction: "150%"}).Validate(); !errors.Is(err, ErrInvalidFraction) {
+		t.Errorf("expected ErrInvalidFraction, got %v", err)
+	}
+
+	valid := []GoldInput{
+		{WeightGrams: "100", Usage: "Investment"},
+		{WeightGrams: "100", Usage: "Investment", InvestmentFraction: "1"},
+		{WeightGrams: "100", Usage: "PersonalUse", InvestmentFraction: "0"},
+		{WeightGrams: "100", Usage: "PersonalUse", InvestmentFraction: "0.5"},
+		{WeightGrams: "100", Usage: "Investment", InvestmentFraction: "0.5"},
+	}
+	for _, in := range valid {
+		if err := in.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", in, err)
+		}
+	}
+
+	if err := (SilverInput{WeightGrams: "600", Usage: "Investment", InvestmentFraction: "0"}).Validate(); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("silver: expected ErrInconsistentInput, got %v", err)
+	}
+	if err := (SilverInput{WeightGrams: "600", Usage: "PersonalUse", InvestmentFraction: "1"}).Validate(); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("silver: expected ErrInconsistentInput, got %v", err)
+	}
+	if err := (SilverInput{WeightGrams: "600", Usage: "PersonalUse", InvestmentFraction: "0.25"}).Validate(); err != nil {
+		t.Errorf("silver: unexpected error %v", err)
+	}
+}
+
+func TestGoldPartialInvestmentShare(t *testing.T) {
+	input := GoldInput{WeightGrams: "200", Purity: "24", Usage: "PersonalUse", InvestmentFraction: "0.5", HawlSatisfied: true}
+
+	shafi, err := CalculateGold(input, NewConfig("100", "1").WithMadhabType(MadhabShafi))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, shafi.NetAssets, "10000", "only the invested half is zakatable under Shafi")
+
+	hanafi, err := CalculateGold(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, hanafi.NetAssets, "20000", "all jewelry is zakatable under Hanafi")
+}
+
+func TestGoldJointOwnership(t *testing.T) {
+	config := NewConfig("100", "1")
+
+	// A 100g piece owned half-and-half: each spouse holds 50g, below the 85g nisab.
+	result, err := CalculateGold(GoldInput{WeightGrams: "100", OwnershipFraction: "0.5", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "5000", "only the owned half should be valued")
+	if result.IsPayable {
+		t.Error("a 50g share should be below the nisab")
+	}
+
+	result, err = CalculateGold(GoldInput{WeightGrams: "400", OwnershipFraction: "50%", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "500", "2.5% of a 200g share")
+
+	if err := (SilverInput{WeightGrams: "100", OwnershipFraction: "150%"}).Validate(); !errors.Is(err, ErrInvalidFraction) {
+		t.Errorf("expected ErrInvalidFraction, got %v", err)
+	}
+}
+
+func TestDisputedLiabilitiesExcludedByDefault(t *testing.T) {
+	input := CashInput{CashOnHand: "10000", Liabilities: "1000", DisputedLiabilities: "2000", HawlSatisfied: true}
+	result, err := CalculateCash(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "9000", "disputed liabilities must not be deducted by default")
+	if len(result.Assumptions) != 1 || !strings.Contains(result.Assumptions[0], "not deducted") {
+		t.Errorf("expected a not-deducted assumption, got %v", result.Assumptions)
+	}
+}
+
+func TestDisputedLiabilitiesIncluded(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.IncludeDisputedLiabilities = true
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:          "10000",
+		Liabilities:         "1000",
+		DisputedLiabilities: "2000",
+		HawlSatisfied:       true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "7000", "disputed liabilities should be deducted")
+	if len(result.Assumptions) != 1 || !strings.Contains(result.Assumptions[0], "deducted (IncludeDisputedLiabilities)") {
+		t.Errorf("expected a deducted assumption, got %v", result.Assumptions)
+	}
+	found := false
+	for _, line := range result.Breakdown {
+		if line.Key == "step-disputed-liabilities" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("missing disputed liabilities breakdown line")
+	}
+}
+
+func TestLiabilitiesSubtractionKeepsFullPrecision(t *testing.T) {
+	input := BusinessInput{
+		CashOnHand:     "12345.123456789",
+		InventoryValue: "0.000000001",
+		Liabilities:    "2345.1234567",
+		HawlSatisfied:  true,
+	}
+	result, err := CalculateBusiness(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+
+	// Compared exactly: the tolerant assertDecimalEqual would hide lost digits.
+	want := ToDecimal("10000.000000090")
+	if !ToDecimal(result.NetAssets).Equal(want) {
+		t.Errorf("net assets lost precision: got %s, want %s", result.NetAssets, want)
+	}
+	if got := ToDecimal(FromDecimal(want)); !got.Equal(want) {
+		t.Errorf("string round-trip lost precision: got %s, want %s", got, want)
+	}
+	if !ToDecimal(result.ZakatDue).Equal(want.Mul(ToDecimal("0.025"))) {
+		t.Errorf("zakat due lost precision: got %s", result.ZakatDue)
+	}
+}
+
+func TestConfigValidateWarnsOnSwappedPrices(t *testing.T) {
+	warnings, err := NewConfig("1", "100").Validate()
+	if err != nil {
+		t.Fatalf("swapped prices should warn, not fail: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != WarningPricesSwapped {
+		t.Errorf("expected a %s warning, got %v", WarningPricesSwapped, warnings)
+	}
+
+	warnings, err = NewConfig("100", "1").Validate()
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("sensible prices should not warn, got %v", warnings)
+	}
+}
+
+func TestPersonalExemptionReducesDue(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PersonalExemption = "4000"
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("net assets above the exemption should be payable")
+	}
+	assertDecimalEqual(t, result.NetAssets, "10000", "net assets are reported before the exemption")
+	assertDecimalEqual(t, result.ZakatDue, "150", "rate applies to net assets minus the exemption")
+	if len(result.Assumptions) == 0 {
+		t.Errorf("expected an assumption note for the exemption")
+	}
+}
+
+func TestPersonalExemptionZeroesDue(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PersonalExemption = "20000"
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("an exemption above net assets should leave nothing payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+
+	config.PersonalExemption = "-1"
+	if _, err := CalculateCash(CashInput{CashOnHand: "10000"}, config); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestCashAccruedSalaryIsZakatable(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "400", AccruedSalary: "9600", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "accrued salary should be in the zakatable base")
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+
+	var found bool
+	for _, line := range result.Breakdown {
+		if line.Key == "step-accrued-salary" && line.Amount == "9600" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an accrued salary breakdown line")
+	}
+}
+
+func TestCashDeferredPurchaseObligations(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "12000", DeferredPurchaseObligations: "2000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "10000", "obligation should reduce net assets")
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+
+	var found bool
+	for _, line := range result.Breakdown {
+		if line.Key == "step-deferred-purchases" && line.Op == OpSubtract && line.Amount == "2000" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a deferred purchase breakdown line")
+	}
+}
+
+func TestIntermediateScaleShiftsBorderlineGold(t *testing.T) {
+	// 113.3333g of 18k is 84.999975g pure: just under the 85g nisab.
+	input := GoldInput{WeightGrams: "113.3333", Purity: "18", Usage: "Investment", HawlSatisfied: true}
+	config := NewConfig("100", "1")
+
+	exact, err := CalculateGold(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if exact.IsPayable {
+		t.Errorf("at full precision the holding is below nisab")
+	}
+	assertDecimalEqual(t, exact.TotalAssets, "8499.9975", "exact total value mismatch")
+
+	config.IntermediateScale = intPtr(2)
+	rounded, err := CalculateGold(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !rounded.IsPayable {
+		t.Errorf("rounding the pure weight to 85.00g should reach nisab")
+	}
+	assertDecimalEqual(t, rounded.TotalAssets, "8500", "rounded total value mismatch")
+
+	// 113.3 x 18/24 = 84.975g pure, a whole 85g at scale 0.
+	config.IntermediateScale = intPtr(0)
+	whole, err := CalculateGold(GoldInput{WeightGrams: "113.3", Purity: "18", Usage: "Investment", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, whole.TotalAssets, "8500", "scale 0 should round to whole units")
+
+	config.IntermediateScale = intPtr(-1)
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for a negative IntermediateScale, got %v", err)
+	}
+	if _, err := CalculateGold(input, config); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption from the calculation, got %v", err)
+	}
+}
+
+func TestMetalNisabInGramsIgnoresPrice(t *testing.T) {
+	// 90g of gold owing 600: the money test depends on the price, the gram test does not.
+	input := GoldInput{WeightGrams: "90", Liabilities: "600", HawlSatisfied: true}
+	tests := []struct {
+		price         string
+		grams         bool
+		payable       bool
+		zakatDue, msg string
+	}{
+		{"100", false, false, "0", "money test: 8400 net is below the 8500 nisab"},
+		{"200", false, true, "435", "money test: 17400 net meets the 17000 nisab"},
+		{"100", true, true, "210", "gram test: 90g meets 85g at a low price"},
+		{"200", true, true, "435", "gram test: 90g meets 85g at a high price"},
+	}
+	for _, tt := range tests {
+		config := NewConfig(tt.price, "1")
+		config.MetalNisabInGrams = tt.grams
+		result, err := CalculateGold(input, config)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.msg, err)
+		}
+		if result.IsPayable != tt.payable {
+			t.Errorf("%s: expected payable=%v", tt.msg, tt.payable)
+		}
+		assertDecimalEqual(t, result.ZakatDue, tt.zakatDue, tt.msg)
+	}
+
+	// 500g of silver is below the 595g nisab whatever the price.
+	config := NewConfig("100", "1000")
+	config.MetalNisabInGrams = true
+	result, err := CalculateSilver(SilverInput{WeightGrams: "500", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Error("500g of silver should be below the gram nisab")
+	}
+}
+
+func TestZakatAsLiability(t *testing.T) {
+	input := BusinessInput{CashOnHand: "20000", InventoryValue: "5000", PriorUnpaidZakat: "625", HawlSatisfied: true}
+
+	config := NewConfig("100", "1")
+	kept, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, kept.NetAssets, "25000", "unpaid zakat is not deducted by default")
+	if len(kept.Assumptions) != 1 {
+		t.Errorf("expected a note on the undeducted prior zakat, got %v", kept.Assumptions)
+	}
+
+	config.ZakatAsLiability = true
+	deducted, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, deducted.NetAssets, "24375", "unpaid zakat should be deducted as a debt")
+	assertDecimalEqual(t, deducted.ZakatDue, "609.375", "zakat_due mismatch")
+	var found bool
+	for _, line := range deducted.Breakdown {
+		found = found || (line.Key == "step-prior-zakat" && line.Op == OpSubtract)
+	}
+	if !found || len(deducted.Assumptions) != 1 {
+		t.Errorf("expected a prior zakat line and note, got %+v / %v", deducted.Breakdown, deducted.Assumptions)
+	}
+
+	cash, err := CalculateCash(CashInput{CashOnHand: "10000", PriorUnpaidZakat: "250", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, cash.NetAssets, "9750", "cash should deduct unpaid zakat too")
+}
@@ -0,0 +1,66 @@
+package zakat
+
+import "testing"
+
+func TestCharityGivenReportedNotDeducted(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", CharityGivenThisYear: "100", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "voluntary charity should not reduce zakat by default")
+
+	var reported bool
+	for _, line := range result.Breakdown {
+		if line.Key == "step-charity-given" && line.Amount == "100" && line.Op == OpInfo {
+			reported = true
+		}
+	}
+	if !reported {
+		t.Errorf("charity given should be reported in the breakdown")
+	}
+}
+
+func TestCharityCountsTowardZakat(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.CharityCountsTowardZakat = true
+
+	result, err := CalculateBusiness(BusinessInput{CashOnHand: "10000", CharityGivenThisYear: "100", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "150", "zakat-intended charity should be credited")
+
+	result, err = CalculateBusiness(BusinessInput{CashOnHand: "10000", CharityGivenThisYear: "400", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "credit is capped at the zakat due")
+}
+
+func TestCharityCountsTowardZakatPooled(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.CharityCountsTowardZakat = true
+
+	portfolio, err := CalculatePortfolio(PortfolioInput{Cash: []CashInput{{CashOnHand: "10000", CharityGivenThisYear: "100", HawlSatisfied: true}}}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, portfolio.ZakatDue, "150", "charity should be credited against the pooled due")
+
+	combined, err := CalculateBusinessesCombined([]BusinessInput{{CashOnHand: "10000", CharityGivenThisYear: "100", HawlSatisfied: true}}, config)
+	if err != nil {
+		t.Fatalf("combined calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, combined.ZakatDue, "150", "charity should be credited against the combined due")
+
+	// Each holding alone is below the 595 silver nisab, so only the pool
+	// has a due to credit against.
+	portfolio, err = CalculatePortfolio(PortfolioInput{Cash: []CashInput{
+		{CashOnHand: "500", CharityGivenThisYear: "5", HawlSatisfied: true},
+		{CashOnHand: "500", CharityGivenThisYear: "10", HawlSatisfied: true},
+	}}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, portfolio.ZakatDue, "10", "charity of sub-nisab holdings should be credited against the pool")
+}
@@ -0,0 +1,71 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// CommodityInput holds non-metal commodities held for trade, such as oil or
+// grain positions.
+//
+// Traded commodities are trade goods (urud al-tijarah), zakatable at 2.5% of
+// market value. This is distinct from agricultural zakat (ushr), which is
+// levied in kind on one's own harvested produce.
+type CommodityInput struct {
+	// MarketValue - current market value of the position
+	MarketValue string
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all commodity amounts are valid non-negative decimals.
+func (in CommodityInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// commodityValues holds the parsed fields of a CommodityInput.
+type commodityValues struct {
+	market, liabilities, disputed decimal.Decimal
+}
+
+func (in CommodityInput) parse() (v commodityValues, err error) {
+	if v.market, err = parseAmount("market_value", in.MarketValue); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// CalculateCommodity calculates zakat on a commodity position as trade goods:
+// 2.5% of (market value - liabilities) when at or above the monetary nisab.
+func CalculateCommodity(input CommodityInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   v.market,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeCommodity,
+		breakdown:     []BreakdownLine{amountLine("step-market-value", "Market Value", v.market, OpAdd)},
+		config:        config,
+	})
+}
@@ -0,0 +1,18 @@
+package zakat
+
+import "testing"
+
+func TestCalculateCommodityAboveNisab(t *testing.T) {
+	result, err := CalculateCommodity(CommodityInput{MarketValue: "50000", Liabilities: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("commodity position above nisab should be payable")
+	}
+	if result.AssetType != AssetTypeCommodity {
+		t.Errorf("AssetType = %q, want %q", result.AssetType, AssetTypeCommodity)
+	}
+	assertDecimalEqual(t, result.NetAssets, "40000", "net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "1000", "zakat_due mismatch")
+}
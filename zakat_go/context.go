@@ -0,0 +1,71 @@
+package zakat
+
+import "context"
+
+// requestIDKey is the context key for request/correlation IDs.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying a request/correlation ID.
+// Results calculated with the context-aware functions echo it in
+// ZakatResult.RequestID. A nil ctx is treated as context.Background().
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any.
+// It is safe to call with a nil ctx.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// CalculateContext calculates any supported input (BusinessInput, GoldInput,
+// ...) and tags the result with the request ID from ctx. It returns the
+// context's error without calculating if ctx is already done. A custom
+// Config.NisabResolver is called with ctx.
+func CalculateContext(ctx context.Context, input any, config Config) (ZakatResult, error) {
+	if err := contextErr(ctx); err != nil {
+		return ZakatResult{}, err
+	}
+	result, err := calculateInput(input, config.withContext(ctx))
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	result.ConfigSnapshot = config
+	result.InputsHash = HashInputs(input, config)
+	result.RequestID, _ = RequestIDFromContext(ctx)
+	return result, nil
+}
+
+// CalculatePortfolioContext is CalculatePortfolio with a context. The request
+// ID from ctx is set on the pooled result and on every component.
+func CalculatePortfolioContext(ctx context.Context, input PortfolioInput, config Config) (PortfolioResult, error) {
+	if err := contextErr(ctx); err != nil {
+		return PortfolioResult{}, err
+	}
+	result, err := CalculatePortfolio(input, config.withContext(ctx))
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	id, _ := RequestIDFromContext(ctx)
+	result.RequestID = id
+	result.ConfigSnapshot = config
+	for i := range result.Components {
+		result.Components[i].RequestID = id
+		result.Components[i].ConfigSnapshot = config
+	}
+	return result, nil
+}
+
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
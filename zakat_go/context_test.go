@@ -0,0 +1,50 @@
+package zakat
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequestIDFlowsToResult(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-42")
+	result, err := CalculateContext(ctx, CashInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.RequestID != "req-42" {
+		t.Errorf("request ID not propagated: got %q", result.RequestID)
+	}
+
+	portfolio, err := CalculatePortfolioContext(ctx, PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "10000", HawlSatisfied: true}},
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if portfolio.RequestID != "req-42" || portfolio.Components[0].RequestID != "req-42" {
+		t.Errorf("request ID not propagated to portfolio: %q / %q", portfolio.RequestID, portfolio.Components[0].RequestID)
+	}
+}
+
+func TestRequestIDAbsentAndNilSafe(t *testing.T) {
+	var ctx context.Context
+	result, err := CalculateContext(ctx, CashInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("nil context failed: %v", err)
+	}
+	if result.RequestID != "" {
+		t.Errorf("expected empty request ID, got %q", result.RequestID)
+	}
+	if _, ok := RequestIDFromContext(context.Background()); ok {
+		t.Errorf("background context should carry no request ID")
+	}
+}
+
+func TestCalculateContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CalculateContext(ctx, CashInput{}, NewConfig("100", "1")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// CooperativeInput holds a member's share balance in an Islamic cooperative
+// or credit union.
+//
+// The share balance is the member's own withdrawable savings, zakatable like
+// cash; it is distinct from listed stocks, which are valued at market.
+type CooperativeInput struct {
+	// ShareBalance - the member's share (savings) balance
+	ShareBalance string
+	// Dividends - dividends received or credited and still held
+	Dividends string
+	// Liabilities - debts due now, including loans from the cooperative
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all cooperative amounts are valid non-negative decimals.
+func (in CooperativeInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// cooperativeValues holds the parsed fields of a CooperativeInput.
+type cooperativeValues struct {
+	shares, dividends, liabilities, disputed decimal.Decimal
+}
+
+func (in CooperativeInput) parse() (v cooperativeValues, err error) {
+	if v.shares, err = parseAmount("share_balance", in.ShareBalance); err != nil {
+		return
+	}
+	if v.dividends, err = parseAmount("dividends", in.Dividends); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// CalculateCooperative calculates zakat on a cooperative share balance:
+// 2.5% of (share balance + dividends - liabilities) when at or above the
+// monetary nisab.
+func CalculateCooperative(input CooperativeInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	breakdown := []BreakdownLine{amountLine("step-share-balance", "Share Balance", v.shares, OpAdd)}
+	if v.dividends.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-dividends", "Dividends", v.dividends, OpAdd))
+	}
+	total := v.shares.Add(v.dividends)
+	breakdown = append(breakdown, amountLine("step-total-cooperative", "Total Cooperative Balance", total, OpResult))
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   total,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeCooperative,
+		breakdown:     breakdown,
+		config:        config,
+	})
+}
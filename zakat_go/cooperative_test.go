@@ -0,0 +1,17 @@
+package zakat
+
+import "testing"
+
+func TestCalculateCooperativeWithDividends(t *testing.T) {
+	input := CooperativeInput{ShareBalance: "9000", Dividends: "1500", Liabilities: "500", HawlSatisfied: true}
+	result, err := CalculateCooperative(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("cooperative balance above nisab should be payable")
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10500", "dividends should be added to the share balance")
+	assertDecimalEqual(t, result.NetAssets, "10000", "net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+}
@@ -0,0 +1,94 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// CrowdfundingInput holds a P2P lending or equity-crowdfunding position.
+//
+// Money lent through a platform is a debt owed to the investor. A debt the
+// investor can expect to recover (dayn qawi) is zakatable each year like
+// cash; one at risk of default (dayn da'if) is not zakatable until it is
+// actually received.
+type CrowdfundingInput struct {
+	// Principal - amount invested and not yet repaid
+	Principal string
+	// AccruedReturns - returns earned but not yet paid out
+	AccruedReturns string
+	// Recoverable - whether the position is performing and expected to be
+	// repaid; false for defaulted or at-risk positions
+	Recoverable bool
+	// Liabilities - debts due now
+	Liabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all crowdfunding amounts are valid non-negative decimals.
+func (in CrowdfundingInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// crowdfundingValues holds the parsed fields of a CrowdfundingInput.
+type crowdfundingValues struct {
+	principal, returns, liabilities decimal.Decimal
+}
+
+func (in CrowdfundingInput) parse() (v crowdfundingValues, err error) {
+	if v.principal, err = parseAmount("principal", in.Principal); err != nil {
+		return
+	}
+	if v.returns, err = parseAmount("accrued_returns", in.AccruedReturns); err != nil {
+		return
+	}
+	v.liabilities, err = parseAmount("liabilities", in.Liabilities)
+	return
+}
+
+// CalculateCrowdfunding calculates zakat on a crowdfunding position. A
+// recoverable position owes 2.5% of (principal + accrued returns -
+// liabilities) when at or above the monetary nisab. An at-risk position
+// owes nothing now; its value is reported in DeferredAmount, to be zakated
+// once recovered.
+func CalculateCrowdfunding(input CrowdfundingInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	value := v.principal.Add(v.returns)
+	if !input.Recoverable {
+		result := exemptResult(AssetTypeCrowdfunding, "At-risk position deferred until recovered",
+			[]string{fmt.Sprintf("Crowdfunding position of %s is at risk (dayn da'if); zakat is due on what is recovered, once received.", value)}, config)
+		result.NisabThreshold = nisab.String()
+		result.DeferredAmount = value.String()
+		return result, nil
+	}
+
+	breakdown := []BreakdownLine{amountLine("step-principal", "Outstanding Principal", v.principal, OpAdd)}
+	if v.returns.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-accrued-returns", "Accrued Returns", v.returns, OpAdd))
+	}
+	return calculateMonetary(monetaryParams{
+		totalAssets:   value,
+		liabilities:   v.liabilities,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeCrowdfunding,
+		breakdown:     breakdown,
+		assumptions:   []string{"Recoverable crowdfunding position zakated like a good debt (dayn qawi)."},
+		config:        config,
+	})
+}
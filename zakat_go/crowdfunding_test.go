@@ -0,0 +1,46 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculateCrowdfundingRecoverable(t *testing.T) {
+	result, err := CalculateCrowdfunding(CrowdfundingInput{
+		Principal:      "8000",
+		AccruedReturns: "2000",
+		Recoverable:    true,
+		Liabilities:    "1000",
+		HawlSatisfied:  true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "principal and returns should both count")
+	assertDecimalEqual(t, result.ZakatDue, "225", "zakat_due mismatch")
+	if result.DeferredAmount != "" {
+		t.Errorf("a recoverable position defers nothing, got %s", result.DeferredAmount)
+	}
+}
+
+func TestCalculateCrowdfundingAtRisk(t *testing.T) {
+	result, err := CalculateCrowdfunding(CrowdfundingInput{
+		Principal:      "8000",
+		AccruedReturns: "2000",
+		HawlSatisfied:  true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Error("an at-risk position should not be payable now")
+	}
+	assertDecimalEqual(t, result.DeferredAmount, "10000", "at-risk value should be deferred")
+	if result.AssetType != AssetTypeCrowdfunding {
+		t.Errorf("asset type mismatch: %s", result.AssetType)
+	}
+
+	if err := (CrowdfundingInput{Principal: "-1"}).Validate(); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// CryptoHolding is one crypto asset in a CryptoInput.
+type CryptoHolding struct {
+	// Name - token name or symbol (e.g., "BTC")
+	Name string
+	// Value - current market value of the holding
+	Value string
+	// Zakatable - whether the holding counts toward zakat. Nil means true;
+	// set it to false for tokens argued to be non-zakatable, such as
+	// governance or NFT-like tokens.
+	Zakatable *bool
+}
+
+// isZakatable reports whether the holding counts, defaulting to true.
+func (h CryptoHolding) isZakatable() bool {
+	return h.Zakatable == nil || *h.Zakatable
+}
+
+// CryptoInput holds crypto assets held for capital appreciation.
+//
+// Cryptocurrencies recognized as wealth (mal) are trade goods (urud
+// al-tijarah), zakatable at 2.5% of market value when at or above the
+// monetary nisab.
+type CryptoInput struct {
+	// Holdings - crypto assets at market value
+	Holdings []CryptoHolding
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all holding values and liabilities are valid
+// non-negative decimals.
+func (in CryptoInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// cryptoValues holds the parsed fields of a CryptoInput.
+type cryptoValues struct {
+	holdings              []decimal.Decimal
+	liabilities, disputed decimal.Decimal
+}
+
+func (in CryptoInput) parse() (v cryptoValues, err error) {
+	for i, holding := range in.Holdings {
+		value, err := parseAmount(fmt.Sprintf("holdings[%d].value", i), holding.Value)
+		if err != nil {
+			return v, err
+		}
+		v.holdings = append(v.holdings, value)
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// CalculateCrypto calculates zakat on crypto holdings as trade goods.
+// Holdings marked non-zakatable are listed in the breakdown but not counted.
+func CalculateCrypto(input CryptoInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	var breakdown []BreakdownLine
+	var assumptions []string
+	total := decimal.Zero
+	for i, holding := range input.Holdings {
+		if !holding.isZakatable() {
+			breakdown = append(breakdown, amountLine("step-non-zakatable-holding", "Non-Zakatable: "+holding.Name, v.holdings[i], OpInfo))
+			assumptions = append(assumptions, fmt.Sprintf("Holding %s of %s excluded as non-zakatable.", holding.Name, v.holdings[i]))
+			continue
+		}
+		breakdown = append(breakdown, amountLine("step-crypto-holding", "Crypto: "+holding.Name, v.holdings[i], OpAdd))
+		total = total.Add(v.holdings[i])
+	}
+	breakdown = append(breakdown, amountLine("step-total-crypto", "Total Zakatable Crypto", total, OpResult))
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   total,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeCrypto,
+		breakdown:     breakdown,
+		assumptions:   assumptions,
+		config:        config,
+	})
+}
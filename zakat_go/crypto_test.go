@@ -0,0 +1,34 @@
+package zakat
+
+import "testing"
+
+func TestCalculateCryptoExcludesNonZakatableHolding(t *testing.T) {
+	excluded := false
+	input := CryptoInput{
+		Holdings: []CryptoHolding{
+			{Name: "BTC", Value: "8000"},
+			{Name: "ETH", Value: "2000"},
+			{Name: "GOV", Value: "5000", Zakatable: &excluded},
+		},
+		HawlSatisfied: true,
+	}
+	result, err := CalculateCrypto(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "excluded holding should not be counted")
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+
+	var shown bool
+	for _, line := range result.Breakdown {
+		if line.Key == "step-non-zakatable-holding" {
+			shown = true
+			if line.Op != OpInfo || line.Amount != "5000" {
+				t.Errorf("unexpected non-zakatable line: %+v", line)
+			}
+		}
+	}
+	if !shown {
+		t.Errorf("excluded holding should appear in the breakdown")
+	}
+}
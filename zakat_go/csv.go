@@ -0,0 +1,51 @@
+package zakat
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// resultCSVColumns are the columns of WriteResultsCSV, named as the
+// FlatMap keys. New columns are only ever appended.
+var resultCSVColumns = []string{
+	"asset_type",
+	"is_payable",
+	"zakat_due",
+	"total_assets",
+	"net_assets",
+	"nisab_threshold",
+	"deferred_amount",
+	"request_id",
+	"inputs_hash",
+	"madhab",
+	"gold_price_per_gram",
+	"silver_price_per_gram",
+}
+
+// WriteResultsCSV writes results as CSV for spreadsheets: a header row, then
+// one row per result with its scalar fields in this fixed order:
+//
+//	asset_type, is_payable, zakat_due, total_assets, net_assets,
+//	nisab_threshold, deferred_amount, request_id, inputs_hash, madhab,
+//	gold_price_per_gram, silver_price_per_gram
+//
+// Values are as in FlatMap. The breakdown and assumptions are not included;
+// use FlatMap for those.
+func WriteResultsCSV(w io.Writer, results []ZakatResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(resultCSVColumns); err != nil {
+		return err
+	}
+	row := make([]string, len(resultCSVColumns))
+	for _, result := range results {
+		flat := result.FlatMap()
+		for i, column := range resultCSVColumns {
+			row[i] = flat[column]
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,51 @@
+package zakat
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestWriteResultsCSVRoundTrip(t *testing.T) {
+	config := NewConfig("100", "1")
+	var results []ZakatResult
+	for _, input := range []any{
+		CashInput{CashOnHand: "10000", HawlSatisfied: true},
+		GoldInput{WeightGrams: "50", HawlSatisfied: true},
+		BusinessInput{CashOnHand: "20000", InventoryValue: "5000", HawlSatisfied: true},
+	} {
+		result, err := calculateInput(input, config)
+		if err != nil {
+			t.Fatalf("calculation failed: %v", err)
+		}
+		results = append(results, result)
+	}
+	results[2].RequestID = `req "quoted", with comma`
+
+	var buf bytes.Buffer
+	if err := WriteResultsCSV(&buf, results); err != nil {
+		t.Fatalf("WriteResultsCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != len(results)+1 {
+		t.Fatalf("expected a header and %d rows, got %d rows", len(results), len(rows))
+	}
+	if got := strings.Join(rows[0], ","); !strings.HasPrefix(got, "asset_type,is_payable,zakat_due,total_assets,net_assets,nisab_threshold") {
+		t.Errorf("unexpected header: %s", got)
+	}
+	for i, result := range results {
+		flat := result.FlatMap()
+		for j, column := range rows[0] {
+			if rows[i+1][j] != flat[column] {
+				t.Errorf("row %d column %s: got %q, want %q", i, column, rows[i+1][j], flat[column])
+			}
+		}
+	}
+	if rows[2][1] != "false" || rows[3][2] != "625" {
+		t.Errorf("unexpected values: gold payable %s, business due %s", rows[2][1], rows[3][2])
+	}
+}
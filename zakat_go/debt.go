@@ -0,0 +1,37 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// DebtOffsetScope selects which assets liabilities are charged against when
+// the owner also declares fixed (non-zakatable) assets.
+type DebtOffsetScope string
+
+const (
+	// DebtOffsetLiquidOnly charges liabilities to liquid, zakatable assets
+	// only, so the full debt reduces the zakatable base. The default.
+	DebtOffsetLiquidOnly DebtOffsetScope = "liquid_only"
+	// DebtOffsetAllAssets spreads liabilities across all assets pro rata,
+	// so only the zakatable share of the debt reduces the base; the rest is
+	// borne by the fixed assets.
+	DebtOffsetAllAssets DebtOffsetScope = "all_assets"
+)
+
+// validate reports an unknown scope. Empty means DebtOffsetLiquidOnly.
+func (s DebtOffsetScope) validate() error {
+	switch s {
+	case "", DebtOffsetLiquidOnly, DebtOffsetAllAssets:
+		return nil
+	default:
+		return fieldError(ErrInvalidOption, "debt_offset_scope", string(s))
+	}
+}
+
+// deductible returns the part of debt that reduces the zakatable base.
+// Under DebtOffsetAllAssets it is debt x zakatable / (zakatable + fixed), to
+// the minor unit of places decimal places.
+func (s DebtOffsetScope) deductible(debt, zakatable, fixed decimal.Decimal, places int32) decimal.Decimal {
+	if s != DebtOffsetAllAssets || !fixed.IsPositive() {
+		return debt
+	}
+	return splitExact(debt, []decimal.Decimal{zakatable, fixed}, places)[0]
+}
@@ -0,0 +1,49 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDebtOffsetScope(t *testing.T) {
+	input := BusinessInput{
+		CashOnHand:    "30000",
+		Liabilities:   "10000",
+		FixedAssets:   "70000",
+		HawlSatisfied: true,
+	}
+	config := NewConfig("100", "1")
+
+	liquidOnly, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, liquidOnly.NetAssets, "20000", "liquid-only scope deducts the full debt")
+
+	config.DebtOffsetScope = DebtOffsetAllAssets
+	allAssets, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	// 30% of assets are zakatable, so 30% of the debt is deducted.
+	assertDecimalEqual(t, allAssets.NetAssets, "27000", "all-assets scope deducts the zakatable share")
+	assertDecimalEqual(t, allAssets.ZakatDue, "675", "zakat_due mismatch")
+
+	input.FixedAssets = ""
+	noFixed, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, noFixed.NetAssets, "20000", "without fixed assets the scope has no effect")
+}
+
+func TestDebtOffsetScopeInvalid(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.DebtOffsetScope = "fixed_first"
+	if _, err := CalculateBusiness(BusinessInput{CashOnHand: "1000"}, config); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption from Validate, got %v", err)
+	}
+}
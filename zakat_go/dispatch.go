@@ -0,0 +1,111 @@
+package zakat
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// canonicalAssetTypes are the asset types Calculate dispatches to.
+var canonicalAssetTypes = map[string]bool{
+	AssetTypeBusiness:        true,
+	AssetTypeGold:            true,
+	AssetTypeSilver:          true,
+	AssetTypeCash:            true,
+	AssetTypePropertyForSale: true,
+	AssetTypeEndOfService:    true,
+	AssetTypeCrypto:          true,
+	AssetTypeCommodity:       true,
+	AssetTypeAgriculture:     true,
+	AssetTypeStocks:          true,
+	AssetTypeCooperative:     true,
+	AssetTypeInsurance:       true,
+	AssetTypeFitr:            true,
+	AssetTypeGemstone:        true,
+	AssetTypeCrowdfunding:    true,
+	AssetTypeStockOption:     true,
+}
+
+var (
+	aliasMu sync.RWMutex
+	// assetTypeAliases maps front-end names to canonical asset types.
+	assetTypeAliases = map[string]string{
+		"savings":        AssetTypeCash,
+		"bank":           AssetTypeCash,
+		"trade_goods":    AssetTypeBusiness,
+		"merchandise":    AssetTypeBusiness,
+		"real_estate":    AssetTypePropertyForSale,
+		"gratuity":       AssetTypeEndOfService,
+		"takaful":        AssetTypeInsurance,
+		"credit_union":   AssetTypeCooperative,
+		"koperasi":       AssetTypeCooperative,
+		"equities":       AssetTypeStocks,
+		"shares":         AssetTypeStocks,
+		"crops":          AssetTypeAgriculture,
+		"harvest":        AssetTypeAgriculture,
+		"commodities":    AssetTypeCommodity,
+		"crypto_assets":  AssetTypeCrypto,
+		"cryptocurrency": AssetTypeCrypto,
+		"jewelry":        AssetTypeGold,
+		"gold_jewelry":   AssetTypeGold,
+		"fitrah":         AssetTypeFitr,
+		"zakat_al_fitr":  AssetTypeFitr,
+		"gemstones":      AssetTypeGemstone,
+		"jewels":         AssetTypeGemstone,
+		"p2p_lending":    AssetTypeCrowdfunding,
+		"stock_options":  AssetTypeStockOption,
+		"esop":           AssetTypeStockOption,
+	}
+)
+
+// RegisterAlias lets Calculate accept alias as a synonym for the canonical
+// asset type, e.g. RegisterAlias("wallet", AssetTypeCash). Names are matched
+// case-insensitively. Registering an existing alias replaces it.
+func RegisterAlias(alias, canonical string) error {
+	canonical = normalizeAssetType(canonical)
+	if !canonicalAssetTypes[canonical] {
+		return fieldError(ErrInvalidOption, "canonical", canonical)
+	}
+	alias = normalizeAssetType(alias)
+	if alias == "" || canonicalAssetTypes[alias] {
+		return fieldError(ErrInvalidOption, "alias", alias)
+	}
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	assetTypeAliases[alias] = canonical
+	return nil
+}
+
+// resolveAssetType returns the canonical asset type for a name or alias.
+func resolveAssetType(assetType string) (string, bool) {
+	name := normalizeAssetType(assetType)
+	if canonicalAssetTypes[name] {
+		return name, true
+	}
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+	canonical, ok := assetTypeAliases[name]
+	return canonical, ok
+}
+
+func normalizeAssetType(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Calculate dispatches input to the calculator for assetType, which may be a
+// canonical asset type or a registered alias. The input must be one of the
+// typed inputs for that asset type, e.g. CashInput for "cash" or "savings".
+func Calculate(assetType string, input any, config Config) (ZakatResult, error) {
+	canonical, ok := resolveAssetType(assetType)
+	if !ok {
+		return ZakatResult{}, fieldError(ErrInvalidOption, "asset_type", assetType)
+	}
+	result, err := calculateInput(input, config)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if result.AssetType != canonical {
+		return ZakatResult{}, fmt.Errorf("%w: %T for asset type %q", ErrInconsistentInput, input, canonical)
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculateAliasMatchesCanonical(t *testing.T) {
+	config := NewConfig("100", "1")
+	input := CashInput{CashOnHand: "10000", HawlSatisfied: true}
+
+	canonical, err := Calculate(AssetTypeCash, input, config)
+	if err != nil {
+		t.Fatalf("canonical dispatch failed: %v", err)
+	}
+	aliased, err := Calculate("Savings", input, config)
+	if err != nil {
+		t.Fatalf("alias dispatch failed: %v", err)
+	}
+	if aliased.AssetType != AssetTypeCash {
+		t.Errorf("alias should resolve to cash, got %q", aliased.AssetType)
+	}
+	assertDecimalEqual(t, aliased.ZakatDue, canonical.ZakatDue, "alias and canonical zakat_due differ")
+}
+
+func TestRegisterAlias(t *testing.T) {
+	if err := RegisterAlias("e_wallet", AssetTypeCash); err != nil {
+		t.Fatalf("RegisterAlias failed: %v", err)
+	}
+	result, err := Calculate("e_wallet", CashInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("dispatch via registered alias failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+
+	if err := RegisterAlias("widgets", "gadgets"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown canonical type, got %v", err)
+	}
+	if err := RegisterAlias(AssetTypeGold, AssetTypeCash); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption when aliasing a canonical type, got %v", err)
+	}
+}
+
+func TestCalculateRejectsMismatchedInput(t *testing.T) {
+	_, err := Calculate("savings", GoldInput{WeightGrams: "100", Purity: "24", Usage: "Investment"}, NewConfig("100", "1"))
+	if !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput, got %v", err)
+	}
+	if _, err := Calculate("unknown", CashInput{}, NewConfig("100", "1")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown asset type, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package zakat
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by the calculators. They are wrapped with the
+// offending field and value, so compare with errors.Is.
+var (
+	// ErrInvalidDecimal is returned when a monetary or weight string is not a valid decimal.
+	ErrInvalidDecimal = errors.New("zakat: invalid decimal")
+	// ErrNegativeValue is returned when an amount that must be non-negative is negative.
+	ErrNegativeValue = errors.New("zakat: negative value")
+	// ErrInvalidPurity is returned when a metal purity is outside its valid range.
+	ErrInvalidPurity = errors.New("zakat: invalid purity")
+	// ErrInvalidUsage is returned when a metal usage is not "Investment" or "PersonalUse".
+	ErrInvalidUsage = errors.New("zakat: invalid usage")
+	// ErrInvalidMadhab is returned when the config names an unknown madhab.
+	ErrInvalidMadhab = errors.New("zakat: invalid madhab")
+	// ErrMissingPrice is returned when a metal price required by the calculation is not set.
+	ErrMissingPrice = errors.New("zakat: missing price")
+	// ErrInvalidOption is returned when a config option names an unknown choice.
+	ErrInvalidOption = errors.New("zakat: invalid option")
+	// ErrInvalidFraction is returned when a fraction is outside the range 0 to 1.
+	ErrInvalidFraction = errors.New("zakat: invalid fraction")
+	// ErrInconsistentInput is returned when input fields contradict each other.
+	ErrInconsistentInput = errors.New("zakat: inconsistent input")
+	// ErrUnsupportedInput is returned when an input value is not a known input type.
+	ErrUnsupportedInput = errors.New("zakat: unsupported input type")
+	// ErrNoSnapshot is returned when a result has no config snapshot to recompute from.
+	ErrNoSnapshot = errors.New("zakat: result has no config snapshot")
+	// ErrInvalidSchedule is returned when a payment plan is requested with no payments.
+	ErrInvalidSchedule = errors.New("zakat: invalid schedule")
+	// ErrOverflow is returned when an amount does not fit the requested integer type.
+	ErrOverflow = errors.New("zakat: amount overflows")
+	// ErrResultMismatch is returned under Config.VerifyResults when the FFI
+	// and pure-Go results diverge beyond the tolerance.
+	ErrResultMismatch = errors.New("zakat: FFI and pure-Go results differ")
+	// ErrMissingFXRate is returned when an amount is in a currency with no
+	// positive rate in Config.FXRates.
+	ErrMissingFXRate = errors.New("zakat: missing FX rate")
+	// ErrFFIUnavailable is returned by tools that need the FFI backend when
+	// it is not loaded.
+	ErrFFIUnavailable = errors.New("zakat: FFI backend unavailable")
+)
+
+// ValidationError is a sentinel error tied to the input field and value that
+// caused it. Calculators return field errors as ValidationError; use
+// errors.Is on the error itself to test the sentinel.
+type ValidationError struct {
+	// Field - snake_case name of the offending field, e.g. "weight_grams"
+	Field string
+	// Value - the rejected value as given
+	Value string
+	// Err - the sentinel error, e.g. ErrNegativeValue
+	Err error
+}
+
+// Error formats the error as "sentinel: field=value".
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%v: %s=%q", e.Err, e.Field, e.Value)
+}
+
+// Unwrap returns the sentinel error.
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError wraps a sentinel error with the field and value that caused it.
+func fieldError(err error, field, value string) error {
+	return ValidationError{Field: field, Value: value, Err: err}
+}
+
+// Warning is an advisory finding about input that is valid but likely a
+// mistake. Calculations still proceed.
+type Warning struct {
+	// Code - stable identifier of the warning
+	Code string
+	// Field - the field the warning concerns
+	Field string
+	// Message - human-readable description
+	Message string
+}
+
+// Warning codes.
+const (
+	// WarningPricesSwapped flags a silver price above the gold price, which
+	// almost always means the two were entered the wrong way round.
+	WarningPricesSwapped = "prices_swapped"
+	// WarningStalePrices flags prices quoted more than Config.MaxPriceAge
+	// before the period end.
+	WarningStalePrices = "stale_prices"
+)
+
+// String formats the warning as "code: message".
+func (w Warning) String() string {
+	return w.Code + ": " + w.Message
+}
@@ -0,0 +1,41 @@
+package zakat
+
+import "time"
+
+// now is the clock used to stamp events; tests replace it.
+var now = time.Now
+
+// CalculationEvent is a calculation recorded as an event, for event-sourced
+// systems. It marshals to JSON with the tagged field names.
+type CalculationEvent struct {
+	// Type - caller-chosen event type, e.g. "zakat.calculated"
+	Type string `json:"type"`
+	// Timestamp - when the event was created (UTC)
+	Timestamp time.Time `json:"timestamp"`
+	// InputsHash - the result's InputsHash, stable for identical inputs
+	InputsHash string `json:"inputs_hash"`
+	// RequestID - correlation ID carried by the result, if any
+	RequestID string `json:"request_id,omitempty"`
+	// Config - the config snapshot the result was calculated with
+	Config Config `json:"config"`
+	// Result - the calculated result
+	Result ZakatResult `json:"result"`
+}
+
+// ToEvent wraps the result in a CalculationEvent of the given type. It only
+// copies data and stamps the current time; nothing is recalculated.
+// ConfigSnapshot.NisabResolver is code, not data, and is dropped.
+//
+// InputsHash is set when the result came from a generic entry point such as
+// Calculate or CalculateContext; otherwise it is empty.
+func (r ZakatResult) ToEvent(eventType string) CalculationEvent {
+	r.ConfigSnapshot.NisabResolver = nil
+	return CalculationEvent{
+		Type:       eventType,
+		Timestamp:  now().UTC(),
+		InputsHash: r.InputsHash,
+		RequestID:  r.RequestID,
+		Config:     r.ConfigSnapshot,
+		Result:     r,
+	}
+}
@@ -0,0 +1,74 @@
+package zakat
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToEventCarriesResultAndStableHash(t *testing.T) {
+	fixed := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	saved := now
+	t.Cleanup(func() { now = saved })
+	now = func() time.Time { return fixed }
+
+	config := NewConfig("100", "1")
+	first, err := Calculate(AssetTypeCash, CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	second, err := Calculate(AssetTypeCash, CashInput{CashOnHand: "10000.00", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+
+	event := first.ToEvent("zakat.calculated")
+	if event.Type != "zakat.calculated" || !event.Timestamp.Equal(fixed) {
+		t.Errorf("unexpected event header: %+v", event)
+	}
+	if event.InputsHash == "" || event.InputsHash != second.ToEvent("zakat.calculated").InputsHash {
+		t.Errorf("equivalent inputs should give the same non-empty hash")
+	}
+	if event.Result.ZakatDue != first.ZakatDue || event.Config.GoldPricePerGram != "100" {
+		t.Errorf("event should carry the result and config snapshot")
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("event should marshal to JSON: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, key := range []string{"type", "timestamp", "inputs_hash", "config", "result"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("event JSON missing %q", key)
+		}
+	}
+}
+
+func TestToEventDropsNisabResolver(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.NisabResolver = fixedNisab{value: "500"}
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+
+	event := result.ToEvent("zakat.calculated")
+	if event.Config.NisabResolver != nil || event.Result.ConfigSnapshot.NisabResolver != nil {
+		t.Errorf("event should not carry the resolver")
+	}
+	if result.ConfigSnapshot.NisabResolver == nil {
+		t.Errorf("ToEvent should not modify the result")
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("event should marshal to JSON: %v", err)
+	}
+	var decoded CalculationEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("event JSON should decode back into a CalculationEvent: %v", err)
+	}
+}
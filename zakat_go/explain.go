@@ -0,0 +1,107 @@
+package zakat
+
+import "context"
+
+// Explanation is a full report of how a result came to be: the result itself
+// alongside the nisab, rate and madhab rules that produced it.
+type Explanation struct {
+	// Result - the calculated result, including Breakdown and Assumptions
+	Result ZakatResult
+	// Rules - the madhab positions the calculation applied, including any
+	// Config.NisabBasis override
+	Rules MadhabInfo
+	// NisabBasis - the metal the nisab for this input is derived from;
+	// empty for produce and fitr, which have no metal nisab
+	NisabBasis NisabBasis
+	// NisabThreshold - the nisab value compared against (string for precision)
+	NisabThreshold string
+	// NisabTola - the metal nisab weight in tolas under NisabUnitTola;
+	// empty otherwise, for produce, or when a NisabResolver sets the nisab
+	NisabTola string
+	// Rate - the rate applied to zakatable wealth (string for precision);
+	// empty for fitr
+	Rate string
+}
+
+// ExplainCalculation calculates input and returns the result together with
+// the rules behind it, in one call. input is any value accepted by
+// Recompute.
+//
+// Rules are the madhab's positions with any Config.NisabBasis override
+// applied. Gold and silver holdings use their own metal's nisab; other
+// monetary inputs report the metal the monetary nisab was actually derived
+// from. Produce, with its weight nisab, and fitr, with none, report no
+// NisabBasis; fitr, a fixed measure per person, reports no Rate.
+func ExplainCalculation(input any, config Config) (Explanation, error) {
+	result, err := calculateInput(input, config)
+	if err != nil {
+		return Explanation{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return Explanation{}, err
+	}
+	rate, err := inputRate(input, config, rules)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{
+		Result:         result,
+		Rules:          rules.info(),
+		NisabThreshold: result.NisabThreshold,
+		Rate:           rate,
+	}
+	monetary := result.AssetType != AssetTypeFitr && resultPool(result) == NisabPoolMonetary
+	switch {
+	case result.AssetType == AssetTypeGold:
+		explanation.NisabBasis = NisabBasisGold
+	case result.AssetType == AssetTypeSilver:
+		explanation.NisabBasis = NisabBasisSilver
+	case monetary && config.NisabResolver != nil:
+		if _, metal, _, err := config.NisabResolver.Nisab(context.Background(), config); err == nil {
+			switch NisabBasis(metal) {
+			case NisabBasisGold, NisabBasisSilver:
+				explanation.NisabBasis = NisabBasis(metal)
+			}
+		}
+	case monetary:
+		if _, metal, _, err := priceNisab(config, rules); err == nil {
+			explanation.NisabBasis = NisabBasis(metal)
+		}
+	}
+	if config.NisabUnit == NisabUnitTola {
+		gold, silver := config.nisabGrams()
+		switch {
+		case result.AssetType == AssetTypeGold:
+			explanation.NisabTola = toTola(gold).String()
+		case result.AssetType == AssetTypeSilver:
+			explanation.NisabTola = toTola(silver).String()
+		case config.NisabResolver == nil && monetary:
+			if _, _, grams, err := priceNisab(config, rules); err == nil {
+				explanation.NisabTola = toTola(grams).String()
+			}
+		}
+	}
+	return explanation, nil
+}
+
+// inputRate returns the rate the calculator for input applies: the
+// irrigation rate for produce (unless valued as trade goods), none for
+// fitr, and the trade-goods rate for everything else.
+func inputRate(input any, config Config, rules zakatRules) (string, error) {
+	switch in := input.(type) {
+	case FitrInput:
+		return "", nil
+	case AgricultureInput:
+		if config.StoredProduceAsTradeGoods && in.HeldForSale {
+			break
+		}
+		v, err := in.parse()
+		if err != nil {
+			return "", err
+		}
+		return v.rate.String(), nil
+	}
+	return rules.tradeGoodsRate.String(), nil
+}
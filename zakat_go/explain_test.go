@@ -0,0 +1,69 @@
+package zakat
+
+import "testing"
+
+func TestExplainCalculationGold(t *testing.T) {
+	config := NewConfig("100", "1")
+	explanation, err := ExplainCalculation(GoldInput{WeightGrams: "100", Purity: "24", Usage: "Investment", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("ExplainCalculation failed: %v", err)
+	}
+
+	if len(explanation.Result.Breakdown) == 0 {
+		t.Errorf("explanation should carry the breakdown")
+	}
+	if explanation.Result.ConfigSnapshot.GoldPricePerGram != config.GoldPricePerGram {
+		t.Errorf("explanation should carry the config snapshot")
+	}
+	if explanation.Rules.Madhab != MadhabHanafi || explanation.Rules.TradeGoodsRate == "" {
+		t.Errorf("explanation should carry the madhab rules, got %+v", explanation.Rules)
+	}
+	if explanation.NisabBasis != NisabBasisGold {
+		t.Errorf("gold should use the gold nisab basis, got %q", explanation.NisabBasis)
+	}
+	assertDecimalEqual(t, explanation.NisabThreshold, "8500", "nisab threshold mismatch")
+	assertDecimalEqual(t, explanation.Rate, "0.025", "rate mismatch")
+	assertDecimalEqual(t, explanation.Result.ZakatDue, "250", "zakat_due mismatch")
+}
+
+func TestExplainCalculationAgriculture(t *testing.T) {
+	explanation, err := ExplainCalculation(AgricultureInput{HarvestWeightKg: "1000", PricePerKg: "2", Irrigation: IrrigationIrrigated}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("ExplainCalculation failed: %v", err)
+	}
+	assertDecimalEqual(t, explanation.Rate, "0.05", "irrigated produce should report the 5% rate")
+	if explanation.NisabBasis != "" {
+		t.Errorf("produce has a weight nisab, not a metal basis; got %q", explanation.NisabBasis)
+	}
+
+	explanation, err = ExplainCalculation(FitrInput{PersonCount: 2, KgPerPerson: "2.5", PricePerKg: "3"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("ExplainCalculation failed: %v", err)
+	}
+	if explanation.Rate != "" || explanation.NisabBasis != "" {
+		t.Errorf("fitr has no rate or nisab basis, got rate %q basis %q", explanation.Rate, explanation.NisabBasis)
+	}
+}
+
+func TestExplainCalculationNisabBasisOverride(t *testing.T) {
+	config := NewConfig("100", "1")
+	cash := CashInput{CashOnHand: "10000", HawlSatisfied: true}
+
+	explanation, err := ExplainCalculation(cash, config)
+	if err != nil {
+		t.Fatalf("ExplainCalculation failed: %v", err)
+	}
+	if explanation.NisabBasis != NisabBasisSilver {
+		t.Errorf("the lower-of-two default should report the silver nisab it picked, got %q", explanation.NisabBasis)
+	}
+
+	config.NisabBasis = NisabBasisGold
+	explanation, err = ExplainCalculation(cash, config)
+	if err != nil {
+		t.Fatalf("ExplainCalculation failed: %v", err)
+	}
+	if explanation.NisabBasis != NisabBasisGold || explanation.Rules.NisabBasis != NisabBasisGold {
+		t.Errorf("the override should show in the basis and rules, got %q and %q", explanation.NisabBasis, explanation.Rules.NisabBasis)
+	}
+	assertDecimalEqual(t, explanation.NisabThreshold, "8500", "gold nisab mismatch")
+}
@@ -0,0 +1,68 @@
+package zakat
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// ffiProbe reports whether the Rust shared library is loaded and its
+// bindings are callable. The uniffi-bindgen-go bindings are not generated
+// yet, so it reports false until they replace it.
+var ffiProbe = func() bool { return false }
+
+// ffiCalculate calculates an input through the Rust library. It is nil
+// until the bindings are generated.
+var ffiCalculate func(input any, config Config) (ZakatResult, error)
+
+// ffiMu serializes calls into the Rust library, which is not assumed to be
+// safe for concurrent use.
+var ffiMu sync.Mutex
+
+// FFIAvailable reports whether the Rust library backend is loaded and
+// callable, without performing a calculation. When it returns false the
+// calculators run on the pure-Go port (see Calculation Backend in the
+// package documentation).
+//
+// It never panics: a probe failure, such as a missing shared library, is
+// reported as unavailable.
+func FFIAvailable() (available bool) {
+	defer func() {
+		if recover() != nil {
+			available = false
+		}
+	}()
+	return ffiProbe()
+}
+
+// defaultVerifyTolerance matches the tolerance of DecimalEqual.
+var defaultVerifyTolerance = decimal.New(1, -7)
+
+// verifyResult recalculates input through the FFI backend and compares its
+// zakat due with the pure-Go result. It is a no-op when the backend is
+// unavailable.
+func verifyResult(input any, config Config, result ZakatResult) (ZakatResult, error) {
+	if ffiCalculate == nil || !FFIAvailable() {
+		result.Assumptions = append(result.Assumptions, "Result verification skipped: FFI backend unavailable.")
+		return result, nil
+	}
+	tolerance := defaultVerifyTolerance
+	if config.VerifyTolerance != "" {
+		var err error
+		if tolerance, err = parseAmount("verify_tolerance", config.VerifyTolerance); err != nil {
+			return ZakatResult{}, err
+		}
+	}
+	ffiMu.Lock()
+	ffiResult, err := ffiCalculate(input, config)
+	ffiMu.Unlock()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	diff := ToDecimal(ffiResult.ZakatDue).Sub(ToDecimal(result.ZakatDue)).Abs()
+	if ffiResult.IsPayable != result.IsPayable || diff.GreaterThan(tolerance) {
+		return ZakatResult{}, fmt.Errorf("%w: zakat_due ffi=%s go=%s", ErrResultMismatch, ffiResult.ZakatDue, result.ZakatDue)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func withFFIProbe(t *testing.T, probe func() bool) {
+	t.Helper()
+	saved := ffiProbe
+	t.Cleanup(func() { ffiProbe = saved })
+	ffiProbe = probe
+}
+
+func TestFFIAvailable(t *testing.T) {
+	withFFIProbe(t, func() bool { return true })
+	if !FFIAvailable() {
+		t.Errorf("expected FFI to be reported available")
+	}
+}
+
+func TestFFIUnavailable(t *testing.T) {
+	withFFIProbe(t, func() bool { return false })
+	if FFIAvailable() {
+		t.Errorf("expected FFI to be reported unavailable")
+	}
+
+	withFFIProbe(t, func() bool { panic("libzakat.so: cannot open shared object file") })
+	if FFIAvailable() {
+		t.Errorf("a panicking probe should be reported unavailable")
+	}
+}
+
+func withFFIBackend(t *testing.T, calculate func(any, Config) (ZakatResult, error)) {
+	t.Helper()
+	withFFIProbe(t, func() bool { return true })
+	saved := ffiCalculate
+	t.Cleanup(func() { ffiCalculate = saved })
+	ffiCalculate = calculate
+}
+
+func TestVerifyResultsAgree(t *testing.T) {
+	withFFIBackend(t, dispatchInput)
+	config := NewConfig("100", "1")
+	config.VerifyResults = true
+
+	result, err := Calculate(AssetTypeCash, CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("agreeing backends should verify: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+}
+
+func TestVerifyResultsMismatch(t *testing.T) {
+	withFFIBackend(t, func(input any, config Config) (ZakatResult, error) {
+		result, err := dispatchInput(input, config)
+		result.ZakatDue = ToDecimal(result.ZakatDue).Add(ToDecimal("0.01")).String()
+		return result, err
+	})
+	config := NewConfig("100", "1")
+	config.VerifyResults = true
+	input := CashInput{CashOnHand: "10000", HawlSatisfied: true}
+
+	if _, err := Calculate(AssetTypeCash, input, config); !errors.Is(err, ErrResultMismatch) {
+		t.Errorf("expected ErrResultMismatch, got %v", err)
+	}
+
+	config.VerifyTolerance = "0.05"
+	if _, err := Calculate(AssetTypeCash, input, config); err != nil {
+		t.Errorf("difference within tolerance should verify: %v", err)
+	}
+}
+
+func TestVerifyResultsPayableMismatch(t *testing.T) {
+	withFFIBackend(t, func(input any, config Config) (ZakatResult, error) {
+		result, err := dispatchInput(input, config)
+		result.IsPayable = !result.IsPayable
+		return result, err
+	})
+	config := NewConfig("100", "1")
+	config.VerifyResults = true
+	config.VerifyTolerance = "1000000"
+
+	// The dues agree within the tolerance, but the backends disagree on
+	// whether anything is payable.
+	_, err := Calculate(AssetTypeGold, GoldInput{WeightGrams: "100", HawlSatisfied: true}, config)
+	if !errors.Is(err, ErrResultMismatch) {
+		t.Errorf("expected ErrResultMismatch, got %v", err)
+	}
+
+	backendErr := errors.New("ffi: calculation failed")
+	withFFIBackend(t, func(any, Config) (ZakatResult, error) { return ZakatResult{}, backendErr })
+	if _, err := Calculate(AssetTypeGold, GoldInput{WeightGrams: "100", HawlSatisfied: true}, config); !errors.Is(err, backendErr) {
+		t.Errorf("expected the backend error, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package zakat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// fitrStaple is the customary staple and measure for Zakat al-Fitr in one
+// region.
+type fitrStaple struct {
+	staple, kgPerPerson string
+}
+
+// Global default: one sa' (about 2.5 kg) of wheat, the usual figure where no
+// local authority sets one.
+var fitrGlobalDefault = fitrStaple{"wheat", "2.5"}
+
+// fitrRegions maps ISO 3166-1 alpha-2 codes to the staple and weight set by
+// the local zakat authority.
+var fitrRegions = map[string]fitrStaple{
+	"ID": {"rice", "2.5"}, // BAZNAS
+	"MY": {"rice", "2.7"},
+}
+
+// FitrRegionDefaults returns the customary staple and kg per person of Zakat
+// al-Fitr for a region, given as an ISO 3166-1 alpha-2 code such as "ID".
+// An empty or unlisted region falls back to the global default of 2.5 kg of
+// wheat; only a malformed code is an error.
+func FitrRegionDefaults(region string) (staple string, kgPerPerson string, err error) {
+	code := strings.ToUpper(strings.TrimSpace(region))
+	if code != "" && !isRegionCode(code) {
+		return "", "", fieldError(ErrInvalidOption, "region", region)
+	}
+	d, ok := fitrRegions[code]
+	if !ok {
+		d = fitrGlobalDefault
+	}
+	return d.staple, d.kgPerPerson, nil
+}
+
+func isRegionCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
+
+// FitrInput holds input values for Zakat al-Fitr, paid per person at the end
+// of Ramadan as a measure of the staple food or its value.
+type FitrInput struct {
+	// PersonCount - number of people paid for (the payer and dependants)
+	PersonCount int
+	// Region - ISO 3166-1 alpha-2 code used to default Staple and
+	// KgPerPerson, see FitrRegionDefaults
+	Region string
+	// Staple - staple food paid in; defaults by Region
+	Staple string
+	// KgPerPerson - measure per person in kg; defaults by Region
+	KgPerPerson string
+	// PricePerKg - local price of the staple per kg
+	PricePerKg string
+}
+
+// Validate checks the person count and that all amounts are valid
+// non-negative decimals.
+func (in FitrInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// fitrValues holds the parsed fields of a FitrInput, with regional defaults
+// applied.
+type fitrValues struct {
+	staple    string
+	kg, price decimal.Decimal
+	defaulted bool
+}
+
+func (in FitrInput) parse() (v fitrValues, err error) {
+	if in.PersonCount < 1 {
+		return v, fieldError(ErrInvalidOption, "person_count", fmt.Sprint(in.PersonCount))
+	}
+	staple, kg, err := FitrRegionDefaults(in.Region)
+	if err != nil {
+		return
+	}
+	v.staple = staple
+	if strings.TrimSpace(in.Staple) != "" {
+		v.staple = in.Staple
+	}
+	if strings.TrimSpace(in.KgPerPerson) != "" {
+		kg = in.KgPerPerson
+	} else {
+		v.defaulted = true
+	}
+	if v.kg, err = parseAmount("kg_per_person", kg); err != nil {
+		return
+	}
+	v.price, err = parseAmount("price_per_kg", in.PricePerKg)
+	return
+}
+
+// CalculateFitr calculates Zakat al-Fitr: persons x kg per person x price
+// per kg. It is obligatory per person, so no nisab, hawl or exemption
+// applies.
+func CalculateFitr(input FitrInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	persons := decimal.NewFromInt(int64(input.PersonCount))
+	due := persons.Mul(v.kg).Mul(v.price)
+	var assumptions []string
+	if v.defaulted {
+		source := "the global default"
+		if _, listed := fitrRegions[strings.ToUpper(strings.TrimSpace(input.Region))]; listed {
+			source = "the default for region " + strings.ToUpper(strings.TrimSpace(input.Region))
+		}
+		assumptions = append(assumptions, fmt.Sprintf("%s kg of %s per person, %s.", v.kg, v.staple, source))
+	}
+	return ZakatResult{
+		AssetType:      AssetTypeFitr,
+		IsPayable:      due.IsPositive(),
+		ZakatDue:       due.String(),
+		TotalAssets:    due.String(),
+		NetAssets:      due.String(),
+		NisabThreshold: "0",
+		Breakdown: []BreakdownLine{
+			quantityLine("step-person-count", "Person Count", persons, OpAdd, UnitCount),
+			quantityLine("step-amount-per-person", "Amount per Person (kg "+v.staple+")", v.kg, OpAdd, UnitKg),
+			amountLine("step-price-per-kg", "Price per kg", v.price, OpAdd),
+			infoLine("info-fitrah-obligatory", "Fitrah is obligatory - no Nisab threshold"),
+			amountLine("status-due", "Zakat Due", due, OpResult),
+		},
+		Assumptions:    assumptions,
+		ConfigSnapshot: config,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFitrRegionDefaults(t *testing.T) {
+	tests := []struct {
+		region, staple, kg string
+	}{
+		{"ID", "rice", "2.5"},
+		{"id", "rice", "2.5"},
+		{"ZZ", "wheat", "2.5"},
+		{"", "wheat", "2.5"},
+	}
+	for _, tt := range tests {
+		staple, kg, err := FitrRegionDefaults(tt.region)
+		if err != nil {
+			t.Fatalf("region %q: %v", tt.region, err)
+		}
+		if staple != tt.staple || kg != tt.kg {
+			t.Errorf("region %q: expected %s %s kg, got %s %s kg", tt.region, tt.staple, tt.kg, staple, kg)
+		}
+	}
+
+	if _, _, err := FitrRegionDefaults("Indonesia"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for a malformed code, got %v", err)
+	}
+}
+
+func TestCalculateFitrRegionDefault(t *testing.T) {
+	// 4 people x 2.5 kg rice x 15000 per kg = 150000.
+	result, err := CalculateFitr(FitrInput{PersonCount: 4, Region: "ID", PricePerKg: "15000"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "150000", "zakat_due mismatch")
+	if !result.IsPayable || result.AssetType != AssetTypeFitr {
+		t.Errorf("unexpected result: payable=%v type=%s", result.IsPayable, result.AssetType)
+	}
+	if len(result.Assumptions) != 1 {
+		t.Errorf("expected the regional default to be recorded, got %v", result.Assumptions)
+	}
+
+	// An explicit measure overrides the region.
+	result, err = CalculateFitr(FitrInput{PersonCount: 4, Region: "ID", KgPerPerson: "3", PricePerKg: "15000"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "180000", "explicit kg should override the region")
+}
+
+func TestCalculateFitrInvalid(t *testing.T) {
+	if err := (FitrInput{PricePerKg: "10"}).Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("zero persons: expected ErrInvalidOption, got %v", err)
+	}
+	if err := (FitrInput{PersonCount: 1, PricePerKg: "-1"}).Validate(); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("negative price: expected ErrNegativeValue, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package zakat
+
+import "strconv"
+
+// FlatMap returns the result as a flat map of string values, for templating
+// into spreadsheets and forms. The keys are stable:
+//
+//	asset_type, is_payable ("true"/"false"), zakat_due, total_assets,
+//	net_assets, nisab_threshold, deferred_amount, request_id, inputs_hash,
+//	madhab, gold_price_per_gram, silver_price_per_gram,
+//	breakdown_count, breakdown.<i>.key, breakdown.<i>.label,
+//	breakdown.<i>.amount, breakdown.<i>.op, breakdown.<i>.unit,
+//	assumption_count, assumptions.<i>
+//
+// where <i> is the zero-based index and the unit is empty for currency
+// amounts. Empty fields are present with an empty
+// value, so every result of the same shape yields the same keys.
+func (r ZakatResult) FlatMap() map[string]string {
+	m := map[string]string{
+		"asset_type":            r.AssetType,
+		"is_payable":            strconv.FormatBool(r.IsPayable),
+		"zakat_due":             r.ZakatDue,
+		"total_assets":          r.TotalAssets,
+		"net_assets":            r.NetAssets,
+		"nisab_threshold":       r.NisabThreshold,
+		"deferred_amount":       r.DeferredAmount,
+		"request_id":            r.RequestID,
+		"inputs_hash":           r.InputsHash,
+		"madhab":                string(r.ConfigSnapshot.Madhab),
+		"gold_price_per_gram":   r.ConfigSnapshot.GoldPricePerGram,
+		"silver_price_per_gram": r.ConfigSnapshot.SilverPricePerGram,
+		"breakdown_count":       strconv.Itoa(len(r.Breakdown)),
+		"assumption_count":      strconv.Itoa(len(r.Assumptions)),
+	}
+	for i, line := range r.Breakdown {
+		prefix := "breakdown." + strconv.Itoa(i) + "."
+		m[prefix+"key"] = line.Key
+		m[prefix+"label"] = line.Label
+		m[prefix+"amount"] = line.Amount
+		m[prefix+"op"] = string(line.Op)
+		m[prefix+"unit"] = string(line.Unit)
+	}
+	for i, assumption := range r.Assumptions {
+		m["assumptions."+strconv.Itoa(i)] = assumption
+	}
+	return m
+}
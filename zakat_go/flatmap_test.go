@@ -0,0 +1,64 @@
+package zakat
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFlatMapBusiness(t *testing.T) {
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:     "10000",
+		InventoryValue: "5000",
+		Liabilities:    "1000",
+		HawlSatisfied:  true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	m := result.FlatMap()
+
+	want := map[string]string{
+		"asset_type":          "business",
+		"is_payable":          "true",
+		"zakat_due":           result.ZakatDue,
+		"net_assets":          "14000",
+		"madhab":              "hanafi",
+		"gold_price_per_gram": "100",
+		"breakdown.0.key":     "step-cash-on-hand",
+		"breakdown.0.amount":  "10000",
+		"breakdown.0.op":      "add",
+		"breakdown.0.unit":    "",
+	}
+	for key, value := range want {
+		if got, ok := m[key]; !ok || got != value {
+			t.Errorf("FlatMap[%q] = %q (present %t), want %q", key, got, ok, value)
+		}
+	}
+	for _, key := range []string{"total_assets", "nisab_threshold", "deferred_amount", "request_id", "breakdown_count", "assumption_count"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("FlatMap missing key %q", key)
+		}
+	}
+	last := "breakdown." + m["breakdown_count"]
+	if _, ok := m[last+".key"]; ok {
+		t.Errorf("FlatMap has a breakdown line past breakdown_count")
+	}
+}
+
+func TestFlatMapBreakdownUnit(t *testing.T) {
+	result, err := CalculateGold(GoldInput{WeightGrams: "100", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	m := result.FlatMap()
+	for i, line := range result.Breakdown {
+		key := "breakdown." + strconv.Itoa(i) + ".unit"
+		if got, ok := m[key]; !ok || got != string(line.Unit) {
+			t.Errorf("FlatMap[%q] = %q (present %t), want %q", key, got, ok, line.Unit)
+		}
+		if line.Unit == UnitGrams {
+			return
+		}
+	}
+	t.Errorf("expected a breakdown line in grams")
+}
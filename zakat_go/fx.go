@@ -0,0 +1,97 @@
+package zakat
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// toBaseCurrency converts amount held in currency to the base currency with
+// Config.FXRates. An empty currency, or Config.BaseCurrency itself, needs no
+// conversion; any other currency must have a rate.
+func (c Config) toBaseCurrency(amount decimal.Decimal, currency, field string) (decimal.Decimal, bool, error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if code == "" || code == strings.ToUpper(strings.TrimSpace(c.BaseCurrency)) {
+		return amount, false, nil
+	}
+	rate, err := c.fxRate(code)
+	if err != nil {
+		return decimal.Zero, false, fieldError(err, field, currency)
+	}
+	converted := amount.Mul(rate)
+	if places, ok := c.fxPlaces(); ok {
+		converted = converted.Round(places)
+	}
+	return converted, true, nil
+}
+
+// fxPlaces returns the decimal places converted amounts are rounded to, or
+// false to keep them exact. See Config.FXScale.
+func (c Config) fxPlaces() (int32, bool) {
+	if c.FXScale != nil {
+		return int32(*c.FXScale), true
+	}
+	places, ok := currencyDecimals[strings.ToUpper(strings.TrimSpace(c.BaseCurrency))]
+	return places, ok
+}
+
+// fxRate returns the parsed rate for an upper-case currency code. Keys that
+// differ only in case or spacing name the same currency; more than one of
+// them is ErrInconsistentInput rather than an arbitrary pick.
+func (c Config) fxRate(code string) (decimal.Decimal, error) {
+	var key string
+	found := false
+	for currency := range c.FXRates {
+		if strings.ToUpper(strings.TrimSpace(currency)) != code {
+			continue
+		}
+		if found {
+			return decimal.Zero, ErrInconsistentInput
+		}
+		key, found = currency, true
+	}
+	if !found {
+		return decimal.Zero, ErrMissingFXRate
+	}
+	rate, err := parseAmount("fx_rates."+key, c.FXRates[key])
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !rate.IsPositive() {
+		return decimal.Zero, ErrMissingFXRate
+	}
+	return rate, nil
+}
+
+// validateFXRates checks that every configured rate is a positive decimal,
+// that no two keys name the same currency, and that FXScale is not
+// negative.
+func (c Config) validateFXRates() error {
+	if c.FXScale != nil && *c.FXScale < 0 {
+		return fieldError(ErrInvalidOption, "fx_scale", strconv.Itoa(*c.FXScale))
+	}
+	currencies := make([]string, 0, len(c.FXRates))
+	for currency := range c.FXRates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	seen := make(map[string]bool, len(currencies))
+	for _, currency := range currencies {
+		s := c.FXRates[currency]
+		rate, err := parseAmount("fx_rates."+currency, s)
+		if err != nil {
+			return err
+		}
+		if !rate.IsPositive() {
+			return fieldError(ErrMissingFXRate, "fx_rates."+currency, s)
+		}
+		code := strings.ToUpper(strings.TrimSpace(currency))
+		if seen[code] {
+			return fieldError(ErrInconsistentInput, "fx_rates."+currency, s)
+		}
+		seen[code] = true
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBusinessInventoryInForeignCurrency(t *testing.T) {
+	config := NewConfig("1000000", "10000")
+	config.BaseCurrency = "IDR"
+	config.FXRates = map[string]string{"USD": "16000"}
+
+	result, err := CalculateBusiness(BusinessInput{
+		CashOnHand:        "40000000",
+		InventoryValue:    "5000",
+		InventoryCurrency: "usd",
+		HawlSatisfied:     true,
+	}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	// 40,000,000 IDR + 5,000 USD x 16,000 = 120,000,000 IDR.
+	assertDecimalEqual(t, result.TotalAssets, "120000000", "inventory should be converted before inclusion")
+	assertDecimalEqual(t, result.ZakatDue, "3000000", "zakat_due mismatch")
+
+	// Inventory already in the base currency needs no rate.
+	result, err = CalculateBusiness(BusinessInput{InventoryValue: "5000", InventoryCurrency: "IDR", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "5000", "base-currency inventory should not be converted")
+}
+
+func TestBusinessInventoryMissingFXRate(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.FXRates = map[string]string{"USD": "1.1"}
+	_, err := CalculateBusiness(BusinessInput{InventoryValue: "5000", InventoryCurrency: "EUR", HawlSatisfied: true}, config)
+	if !errors.Is(err, ErrMissingFXRate) {
+		t.Errorf("expected ErrMissingFXRate, got %v", err)
+	}
+
+	config.FXRates = map[string]string{"USD": "0"}
+	if _, err := config.Validate(); !errors.Is(err, ErrMissingFXRate) {
+		t.Errorf("expected ErrMissingFXRate for a zero rate, got %v", err)
+	}
+}
+
+func TestFXScale(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.BaseCurrency = "USD"
+	config.FXRates = map[string]string{"EUR": "1.0873"}
+	input := BusinessInput{InventoryValue: "1234.56", InventoryCurrency: "EUR", HawlSatisfied: true}
+
+	// 1234.56 EUR x 1.0873 = 1342.337088 USD.
+	tests := []struct {
+		scale *int
+		want  string
+	}{
+		{nil, "1342.34"},
+		{intPtr(0), "1342"},
+		{intPtr(4), "1342.3371"},
+		{intPtr(6), "1342.337088"},
+	}
+	for i, tt := range tests {
+		config.FXScale = tt.scale
+		result, err := CalculateBusiness(input, config)
+		if err != nil {
+			t.Fatalf("case %d: calculation failed: %v", i, err)
+		}
+		if result.TotalAssets != tt.want {
+			t.Errorf("case %d: expected %s, got %s", i, tt.want, result.TotalAssets)
+		}
+	}
+
+	config.FXScale = intPtr(-1)
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for a negative FXScale, got %v", err)
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestFXRatesRejectCaseCollisions(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.FXRates = map[string]string{"USD": "1.1", "usd": "1.2"}
+	if _, err := config.Validate(); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput from Validate, got %v", err)
+	}
+	_, err := CalculateBusiness(BusinessInput{InventoryValue: "5000", InventoryCurrency: "USD", HawlSatisfied: true}, config)
+	if !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput from the calculation, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// GemstoneInput holds precious stones such as diamonds, rubies or pearls.
+//
+// Gemstones are not zakatable in themselves: unlike gold and silver they are
+// not monetary metals, so stones worn or kept for personal use are exempt by
+// consensus. Stones held for trade are trade goods (urud al-tijarah) and
+// zakatable at 2.5% of market value.
+type GemstoneInput struct {
+	// MarketValue - current market value of the stones
+	MarketValue string
+	// ForTrade - whether the stones are held for sale
+	ForTrade bool
+	// Liabilities - debts due now
+	Liabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all gemstone amounts are valid non-negative decimals.
+func (in GemstoneInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// gemstoneValues holds the parsed fields of a GemstoneInput.
+type gemstoneValues struct {
+	market, liabilities decimal.Decimal
+}
+
+func (in GemstoneInput) parse() (v gemstoneValues, err error) {
+	if v.market, err = parseAmount("market_value", in.MarketValue); err != nil {
+		return
+	}
+	v.liabilities, err = parseAmount("liabilities", in.Liabilities)
+	return
+}
+
+// CalculateGemstone calculates zakat on gemstones. Personal stones are
+// exempt; stones held for trade owe 2.5% of (market value - liabilities)
+// when at or above the monetary nisab.
+func CalculateGemstone(input GemstoneInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if !input.ForTrade {
+		return exemptResult(AssetTypeGemstone, "Exempt: gemstones for personal use",
+			[]string{"Gemstones not held for trade are not zakatable."}, config), nil
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   v.market,
+		liabilities:   v.liabilities,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeGemstone,
+		breakdown:     []BreakdownLine{amountLine("step-market-value", "Market Value", v.market, OpAdd)},
+		assumptions:   []string{"Gemstones held for trade valued as trade goods at market value."},
+		config:        config,
+	})
+}
@@ -0,0 +1,40 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculateGemstonePersonalExempt(t *testing.T) {
+	result, err := CalculateGemstone(GemstoneInput{MarketValue: "50000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Error("personal gemstones should be exempt")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+	if len(result.Breakdown) != 1 || result.Breakdown[0].Key != "status-exempt" {
+		t.Errorf("expected an exemption line, got %+v", result.Breakdown)
+	}
+}
+
+func TestCalculateGemstoneForTrade(t *testing.T) {
+	result, err := CalculateGemstone(GemstoneInput{
+		MarketValue:   "50000",
+		ForTrade:      true,
+		Liabilities:   "10000",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable || result.AssetType != AssetTypeGemstone {
+		t.Errorf("unexpected result: payable=%v type=%s", result.IsPayable, result.AssetType)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "1000", "zakat_due mismatch")
+
+	if err := (GemstoneInput{MarketValue: "-1"}).Validate(); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ValuedItem is a single appraised item.
+type ValuedItem struct {
+	// Label - item description shown in the breakdown
+	Label string
+	// MarketValue - appraised market value of the item
+	MarketValue string
+}
+
+// GoldValuedItemsInput holds gold items known by appraised value rather than
+// by weight, such as pieces valued by a jeweler.
+type GoldValuedItemsInput struct {
+	// Items - appraised gold items
+	Items []ValuedItem
+	// Usage - "Investment" or "PersonalUse"
+	Usage string
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// goldItemsValues holds the parsed fields of a GoldValuedItemsInput.
+type goldItemsValues struct {
+	values                []decimal.Decimal
+	liabilities, disputed decimal.Decimal
+	personalUse           bool
+}
+
+func (in GoldValuedItemsInput) parse() (v goldItemsValues, err error) {
+	for i, item := range in.Items {
+		value, err := parseAmount(fmt.Sprintf("items[%d].market_value", i), item.MarketValue)
+		if err != nil {
+			return v, err
+		}
+		v.values = append(v.values, value)
+	}
+	switch in.Usage {
+	case "", usageInvest:
+	case usagePersonal:
+		v.personalUse = true
+	default:
+		return v, fieldError(ErrInvalidUsage, "usage", in.Usage)
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// Validate checks the item values, usage and liabilities.
+func (in GoldValuedItemsInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// CalculateGoldValuedItems calculates zakat on gold from appraised item values,
+// bypassing weight x purity x price. The jewelry exemption, the gold nisab
+// and the rate apply as in CalculateGold: the nisab is valued at the price
+// Config.MetalValuationPrice selects, and under Config.MetalNisabInGrams it
+// is tested on the pure-gold weight the total value buys at that price.
+func CalculateGoldValuedItems(input GoldValuedItemsInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	market, _, err := config.prices()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	gold, note, err := config.valuationPrice("gold", market, config.GoldBuyBackPricePerGram)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if !gold.IsPositive() {
+		return ZakatResult{}, fieldError(ErrMissingPrice, "gold_price_per_gram", config.GoldPricePerGram)
+	}
+	if v.personalUse && rules.jewelryExempt {
+		return exemptResult(AssetTypeGold, "Exempt per Madhab (Huliyy al-Mubah)",
+			[]string{"Personal-use jewelry is exempt under the configured madhab."}, config), nil
+	}
+
+	nisabGrams, _ := config.nisabGrams()
+	var breakdown []BreakdownLine
+	total := decimal.Zero
+	for i, item := range input.Items {
+		breakdown = append(breakdown, amountLine("step-appraised-item", "Appraised: "+item.Label, v.values[i], OpAdd))
+		total = total.Add(v.values[i])
+	}
+	breakdown = append(breakdown, amountLine("step-total-value", "Total Value", total, OpResult))
+	assumptions := []string{"Gold valued from appraised item values rather than weight and purity."}
+	if note != "" {
+		assumptions = append(assumptions, note)
+	}
+	// The pure-gold weight the appraised value buys, for a nisab by weight.
+	weight := config.roundIntermediate(total.Div(gold))
+	if config.MetalNisabInGrams {
+		breakdown = append(breakdown,
+			amountLine("step-price-per-gram", "Price per gram", gold, OpInfo),
+			quantityLine("step-equivalent-weight", "Equivalent Pure Weight", weight, OpInfo, UnitGrams),
+		)
+	}
+	nisabMet, breakdown, assumptions := metalNisab(weight, nisabGrams, breakdown, assumptions, config)
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   total,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisabGrams.Mul(gold),
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		nisabMet:      nisabMet,
+		assetType:     AssetTypeGold,
+		breakdown:     breakdown,
+		assumptions:   assumptions,
+		config:        config,
+	})
+}
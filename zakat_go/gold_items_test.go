@@ -0,0 +1,129 @@
+package zakat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCalculateGoldValuedItemsSumsThreeItems(t *testing.T) {
+	input := GoldValuedItemsInput{
+		Items: []ValuedItem{
+			{Label: "Necklace", MarketValue: "4500"},
+			{Label: "Bracelet", MarketValue: "3000.50"},
+			{Label: "Ring", MarketValue: "1499.50"},
+		},
+		Usage:         "Investment",
+		HawlSatisfied: true,
+	}
+	result, err := CalculateGoldValuedItems(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "9000", "total_assets mismatch")
+	assertDecimalEqual(t, result.NisabThreshold, "8500", "gold nisab mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "225", "zakat_due mismatch")
+
+	items := 0
+	for _, line := range result.Breakdown {
+		if line.Key == "step-appraised-item" {
+			items++
+		}
+	}
+	if items != 3 {
+		t.Errorf("expected 3 appraised item lines, got %d", items)
+	}
+}
+
+func TestCalculateGoldValuedItemsPersonalUse(t *testing.T) {
+	input := GoldValuedItemsInput{
+		Items:         []ValuedItem{{Label: "Bangles", MarketValue: "20000"}},
+		Usage:         "PersonalUse",
+		HawlSatisfied: true,
+	}
+	shafi, err := CalculateGoldValuedItems(input, NewConfig("100", "1").WithMadhabType(MadhabShafi))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if shafi.IsPayable {
+		t.Errorf("personal jewelry should be exempt under Shafi")
+	}
+	hanafi, err := CalculateGoldValuedItems(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, hanafi.ZakatDue, "500", "Hanafi zakat_due mismatch")
+}
+
+func TestCalculateGoldValuedItemsInvalidValue(t *testing.T) {
+	input := GoldValuedItemsInput{Items: []ValuedItem{{Label: "Ring", MarketValue: "-1"}}}
+	if _, err := CalculateGoldValuedItems(input, NewConfig("100", "1")); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestCalculateGoldValuedItemsNisabInGrams(t *testing.T) {
+	// 9000 buys 90 grams at 100 per gram, over the 85-gram nisab, though
+	// the 8000 left after debts is below the 8500 nisab by price.
+	input := GoldValuedItemsInput{
+		Items:         []ValuedItem{{Label: "Necklace", MarketValue: "9000"}},
+		Liabilities:   "1000",
+		HawlSatisfied: true,
+	}
+	config := NewConfig("100", "1")
+	byPrice, err := CalculateGoldValuedItems(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if byPrice.IsPayable {
+		t.Errorf("net value below the nisab by price should not be payable")
+	}
+
+	config.MetalNisabInGrams = true
+	byWeight, err := CalculateGoldValuedItems(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !byWeight.IsPayable {
+		t.Errorf("90 grams should meet the nisab under MetalNisabInGrams")
+	}
+	assertDecimalEqual(t, byWeight.ZakatDue, "200", "zakat_due mismatch")
+	found := false
+	for _, line := range byWeight.Breakdown {
+		if line.Key == "step-equivalent-weight" {
+			found = line.Unit == UnitGrams
+			assertDecimalEqual(t, line.Amount, "90", "equivalent weight mismatch")
+		}
+	}
+	if !found {
+		t.Errorf("expected an equivalent weight line in grams")
+	}
+}
+
+func TestCalculateGoldValuedItemsBuyBackValuation(t *testing.T) {
+	input := GoldValuedItemsInput{
+		Items:         []ValuedItem{{Label: "Bracelet", MarketValue: "8000"}},
+		HawlSatisfied: true,
+	}
+	config := NewConfig("100", "1")
+	config.GoldBuyBackPricePerGram = "90"
+	market, err := CalculateGoldValuedItems(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, market.NisabThreshold, "8500", "market-price nisab mismatch")
+	if market.IsPayable {
+		t.Errorf("8000 should be below the nisab at the market price")
+	}
+
+	config.MetalValuationPrice = ValuationBuyBack
+	buyBack, err := CalculateGoldValuedItems(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, buyBack.NisabThreshold, "7650", "buy-back nisab mismatch")
+	assertDecimalEqual(t, buyBack.ZakatDue, "200", "zakat_due mismatch")
+	if !strings.Contains(strings.Join(buyBack.Assumptions, "\n"), "MetalValuationPrice") {
+		t.Errorf("expected the valuation choice in the assumptions, got %v", buyBack.Assumptions)
+	}
+}
@@ -0,0 +1,122 @@
+package zakat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HashInputs returns a reproducible hash of an input and config, suitable
+// for caching and idempotency keys.
+//
+// The algorithm is SHA-256 (hex-encoded) over canonical JSON of
+// {"type": <input type name>, "input": ..., "config": ...}, where:
+//
+//   - every string that parses as a decimal is rewritten in its shortest
+//     form, so "50000", "50000.00" and " 50000 " hash identically;
+//   - the madhab is replaced by its canonical name ("Shafi'i" -> "shafi");
+//   - times are UTC RFC 3339 with nanoseconds;
+//   - struct fields keep their Go names and objects have sorted keys;
+//   - function and channel fields are ignored.
+//
+// Any material change to an amount, flag or option changes the hash.
+func HashInputs(input any, config Config) string {
+	canonical := map[string]any{
+		"type":   fmt.Sprintf("%T", input),
+		"input":  canonicalValue(reflect.ValueOf(input)),
+		"config": canonicalValue(reflect.ValueOf(config)),
+	}
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		// canonicalValue only produces JSON-safe values.
+		panic(fmt.Sprintf("zakat: canonical JSON failed: %v", err))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	madhabType = reflect.TypeOf(Madhab(""))
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// canonicalValue converts v into plain JSON values with decimals and enums
+// normalized.
+func canonicalValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).UTC().Format(time.RFC3339Nano)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if v.Type() == madhabType {
+			if rules, err := rulesFor(Madhab(s)); err == nil {
+				return string(rules.madhab)
+			}
+			return s
+		}
+		if d, err := decimal.NewFromString(strings.TrimSpace(s)); err == nil {
+			return d.String()
+		}
+		return s
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Sprint(f)
+		}
+		return decimal.NewFromFloat(f).String()
+	case reflect.Struct:
+		fields := make(map[string]any)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || ignoredKind(field.Type.Kind()) {
+				continue
+			}
+			fields[field.Name] = canonicalValue(v.Field(i))
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []any{}
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = canonicalValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries[fmt.Sprint(canonicalValue(iter.Key()))] = canonicalValue(iter.Value())
+		}
+		return entries
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return canonicalValue(v.Elem())
+	default:
+		return nil
+	}
+}
+
+func ignoredKind(k reflect.Kind) bool {
+	return k == reflect.Func || k == reflect.Chan || k == reflect.UnsafePointer
+}
@@ -0,0 +1,40 @@
+package zakat
+
+import "testing"
+
+func TestHashInputsScaleInsensitive(t *testing.T) {
+	a := BusinessInput{CashOnHand: "50000", InventoryValue: "1200.5", HawlSatisfied: true}
+	b := BusinessInput{CashOnHand: "50000.00", InventoryValue: "1200.50", HawlSatisfied: true}
+	if HashInputs(a, NewConfig("100", "1")) != HashInputs(b, NewConfig("100.000", "1.0")) {
+		t.Errorf("scale-different but equal inputs should hash identically")
+	}
+	if HashInputs(a, NewConfig("100", "1").WithMadhab("Shafi'i")) != HashInputs(a, NewConfig("100", "1").WithMadhabType(MadhabShafi)) {
+		t.Errorf("madhab aliases should hash identically")
+	}
+}
+
+func TestHashInputsMaterialChanges(t *testing.T) {
+	config := NewConfig("100", "1")
+	base := BusinessInput{CashOnHand: "50000", HawlSatisfied: true}
+	hash := HashInputs(base, config)
+
+	changed := []struct {
+		name  string
+		input any
+		cfg   Config
+	}{
+		{"amount", BusinessInput{CashOnHand: "50000.01", HawlSatisfied: true}, config},
+		{"flag", BusinessInput{CashOnHand: "50000"}, config},
+		{"type", CashInput{CashOnHand: "50000", HawlSatisfied: true}, config},
+		{"price", base, NewConfig("101", "1")},
+		{"madhab", base, config.WithMadhabType(MadhabShafi)},
+	}
+	for _, tt := range changed {
+		if HashInputs(tt.input, tt.cfg) == hash {
+			t.Errorf("%s change should alter the hash", tt.name)
+		}
+	}
+	if HashInputs(base, config) != hash {
+		t.Errorf("hash should be reproducible")
+	}
+}
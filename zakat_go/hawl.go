@@ -0,0 +1,105 @@
+package zakat
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// defaultHawlDays is the mean length of a lunar year in days.
+const defaultHawlDays = 354.37
+
+// maxHawlDays bounds Config.HawlDays: comfortably above a solar year, and
+// far below the roughly 106,000 days a time.Duration can hold.
+const maxHawlDays = 400
+
+// hawlDuration returns Config.HawlDays as a duration, defaulting to the mean
+// lunar year.
+func (c Config) hawlDuration() (time.Duration, error) {
+	days := c.HawlDays
+	if days == 0 {
+		days = defaultHawlDays
+	}
+	if days < 0 || days > maxHawlDays || math.IsNaN(days) || math.IsInf(days, 0) {
+		return 0, fieldError(ErrInvalidOption, "hawl_days", fmt.Sprint(c.HawlDays))
+	}
+	return time.Duration(days * float64(24*time.Hour)), nil
+}
+
+// HawlDueDate returns when a hawl started at start completes, Config.HawlDays
+// later.
+func HawlDueDate(start time.Time, config Config) (time.Time, error) {
+	d, err := config.hawlDuration()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.Add(d), nil
+}
+
+// HawlComplete reports whether a hawl started at start has completed by
+// asOf, for setting the HawlSatisfied input flags.
+func HawlComplete(start, asOf time.Time, config Config) (bool, error) {
+	due, err := HawlDueDate(start, config)
+	if err != nil {
+		return false, err
+	}
+	return !asOf.Before(due), nil
+}
+
+// asOf returns Config.PeriodEnd, or the current time when it is unset.
+func (c Config) asOf() time.Time {
+	if c.PeriodEnd.IsZero() {
+		return now()
+	}
+	return c.PeriodEnd
+}
+
+// HawlCompleteAtPeriodEnd reports whether a hawl started at start has
+// completed by Config.PeriodEnd (the current time when unset). Setting
+// PeriodEnd gives every item of a statement the same as-of date however
+// long the run takes.
+func HawlCompleteAtPeriodEnd(start time.Time, config Config) (bool, error) {
+	return HawlComplete(start, config.asOf(), config)
+}
+
+// DeferralInfo describes how long a zakat payment has been outstanding, for
+// reminders. It is factual only: a late payment is still owed in full and
+// nothing accrues on it.
+type DeferralInfo struct {
+	// Due - the outstanding amount, unchanged by the delay
+	Due string
+	// DaysOverdue - days since the payment fell due
+	DaysOverdue int
+	// MissedAnniversaries - further hawl anniversaries that have passed
+	// while the payment was outstanding
+	MissedAnniversaries int
+	// Message - a short reminder to pay promptly
+	Message string
+}
+
+// DeferralImpact reports how long the zakat due has been deferred. Zakat
+// should be paid promptly once the hawl completes; each missed anniversary
+// (a hawl of Config.HawlDays, by default the mean lunar year of 354.37
+// days) is a year the payment has been outstanding, not a multiplier on the
+// amount.
+func DeferralImpact(due string, daysLate int, config Config) (DeferralInfo, error) {
+	hawl, err := config.hawlDuration()
+	if err != nil {
+		return DeferralInfo{}, err
+	}
+	days := max(daysLate, 0)
+	info := DeferralInfo{
+		Due:                 due,
+		DaysOverdue:         days,
+		MissedAnniversaries: int(float64(days) / (hawl.Hours() / 24)),
+	}
+	switch {
+	case days == 0:
+		info.Message = "Zakat is due now; paying promptly is recommended."
+	case info.MissedAnniversaries == 0:
+		info.Message = fmt.Sprintf("Zakat of %s has been due for %d days; please pay it as soon as you can.", due, days)
+	default:
+		info.Message = fmt.Sprintf("Zakat of %s has been due for %d days, across %d hawl anniversaries; please pay it as soon as you can.", due, days, info.MissedAnniversaries)
+	}
+	return info, nil
+}
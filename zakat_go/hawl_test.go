@@ -0,0 +1,157 @@
+package zakat
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHawlDueDateDefault(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	due, err := HawlDueDate(start, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("HawlDueDate failed: %v", err)
+	}
+	// 354.37 days = 354 days 8h52m48s.
+	want := start.AddDate(0, 0, 354).Add(8*time.Hour + 52*time.Minute + 48*time.Second)
+	if !due.Equal(want) {
+		t.Errorf("expected %v, got %v", want, due)
+	}
+}
+
+func TestHawlDaysOverrideShiftsBorderline(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	asOf := start.AddDate(0, 0, 354).Add(time.Hour)
+
+	config := NewConfig("100", "1")
+	done, err := HawlComplete(start, asOf, config)
+	if err != nil {
+		t.Fatalf("HawlComplete failed: %v", err)
+	}
+	if done {
+		t.Error("354 days and 1 hour should not complete a 354.37-day hawl")
+	}
+
+	config.HawlDays = 354
+	if done, _ = HawlComplete(start, asOf, config); !done {
+		t.Error("354 days and 1 hour should complete a 354-day hawl")
+	}
+}
+
+func TestHawlDaysInvalid(t *testing.T) {
+	for _, days := range []float64{-1, 401, 1e6, math.NaN(), math.Inf(1)} {
+		config := NewConfig("100", "1")
+		config.HawlDays = days
+		if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("HawlDays %v: expected ErrInvalidOption, got %v", days, err)
+		}
+		if _, err := HawlDueDate(time.Now(), config); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("HawlDays %v: expected ErrInvalidOption from HawlDueDate, got %v", days, err)
+		}
+	}
+}
+
+func TestDeferralImpact(t *testing.T) {
+	tests := []struct {
+		daysLate, days, missed int
+	}{
+		{0, 0, 0},
+		{-3, 0, 0},
+		{30, 30, 0},
+		{354, 354, 0},
+		{355, 355, 1},
+		{800, 800, 2},
+	}
+	for _, tt := range tests {
+		info, err := DeferralImpact("250", tt.daysLate, NewConfig("100", "1"))
+		if err != nil {
+			t.Fatalf("%d days late: %v", tt.daysLate, err)
+		}
+		if info.DaysOverdue != tt.days || info.MissedAnniversaries != tt.missed {
+			t.Errorf("%d days late: expected %d days and %d anniversaries, got %d and %d",
+				tt.daysLate, tt.days, tt.missed, info.DaysOverdue, info.MissedAnniversaries)
+		}
+		if info.Due != "250" {
+			t.Errorf("%d days late: due should be unchanged, got %s", tt.daysLate, info.Due)
+		}
+	}
+}
+
+func TestDeferralImpactSolarHawl(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.HawlDays = 365.25
+	tests := []struct{ daysLate, missed int }{
+		{360, 0},
+		{365, 0},
+		{366, 1},
+		{731, 2},
+	}
+	for _, tt := range tests {
+		info, err := DeferralImpact("250", tt.daysLate, config)
+		if err != nil {
+			t.Fatalf("%d days late: %v", tt.daysLate, err)
+		}
+		if info.MissedAnniversaries != tt.missed {
+			t.Errorf("%d days late: expected %d anniversaries, got %d", tt.daysLate, tt.missed, info.MissedAnniversaries)
+		}
+	}
+
+	config.HawlDays = -1
+	if _, err := DeferralImpact("250", 10, config); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestHawlCompleteAtPeriodEndIsSharedByBatch(t *testing.T) {
+	periodEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	// A clock that moves a week on every read, as in a long-running batch.
+	clock := periodEnd.AddDate(0, 0, -3)
+	saved := now
+	t.Cleanup(func() { now = saved })
+	now = func() time.Time {
+		clock = clock.AddDate(0, 0, 7)
+		return clock
+	}
+
+	// Every hawl is due the day after the period end.
+	due := periodEnd.AddDate(0, 0, 1)
+	config := NewConfig("100", "1")
+	config.HawlDays = 354
+	config.PeriodEnd = periodEnd
+	for i := 0; i < 5; i++ {
+		done, err := HawlCompleteAtPeriodEnd(due.AddDate(0, 0, -354), config)
+		if err != nil {
+			t.Fatalf("item %d: %v", i, err)
+		}
+		if done {
+			t.Errorf("item %d: hawl due after the period end should not be complete", i)
+		}
+	}
+
+	// Without a period end each read sees a later clock and the answer drifts.
+	config.PeriodEnd = time.Time{}
+	if done, _ := HawlCompleteAtPeriodEnd(due.AddDate(0, 0, -354), config); !done {
+		t.Error("the advancing clock should have passed the due date")
+	}
+}
+
+func TestValidateWarnsOnStalePrices(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PeriodEnd = time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	config.PricesAt = config.PeriodEnd.AddDate(0, 0, -10)
+	config.MaxPriceAge = 7 * 24 * time.Hour
+
+	warnings, err := config.Validate()
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != WarningStalePrices {
+		t.Errorf("expected a stale-prices warning, got %v", warnings)
+	}
+
+	config.PricesAt = config.PeriodEnd.AddDate(0, 0, -2)
+	if warnings, _ = config.Validate(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for recent prices, got %v", warnings)
+	}
+}
@@ -0,0 +1,47 @@
+package zakat
+
+import "time"
+
+// hijriRamadan is the ninth month of the Hijri calendar.
+const hijriRamadan = 9
+
+// hijriDate converts t's calendar date to the tabular (arithmetical) Hijri
+// calendar. Months observed by moon sighting can start a day or two either
+// side of the tabular date, so this is for presentation only.
+func hijriDate(t time.Time) (year, month, day int) {
+	// Julian day number of the Gregorian date.
+	a := (14 - int(t.Month())) / 12
+	y := t.Year() + 4800 - a
+	m := int(t.Month()) + 12*a - 3
+	jdn := t.Day() + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+
+	// Tabular Islamic calendar, civil epoch (16 July 622).
+	l := jdn - 1948440 + 10632
+	n := (l - 1) / 10631
+	l = l - 10631*n + 354
+	j := ((10985-l)/5316)*((50*l)/17719) + (l/5670)*((43*l)/15238)
+	l = l - ((30-j)/15)*((17719*j)/50) - (j/16)*((15238*j)/43) + 29
+	month = (24 * l) / 709
+	day = l - (709*month)/24
+	year = 30*n + j - 30
+	return year, month, day
+}
+
+// ramadanNote is the timing note shown during Ramadan.
+const ramadanNote = "It is Ramadan: many choose to pay their zakat now, as good deeds in Ramadan carry greater reward. The amount due is the same whenever it is paid."
+
+// TimingNote returns a short factual note about paying the zakat due now,
+// when asOf falls in Ramadan, and an empty string otherwise or when nothing
+// is due. It is presentation only: the month never changes the amount.
+//
+// Ramadan is determined with the tabular Hijri calendar, which can differ by
+// a day or two from a locally sighted start or end of the month.
+func (r ZakatResult) TimingNote(asOf time.Time) string {
+	if !r.IsPayable {
+		return ""
+	}
+	if _, month, _ := hijriDate(asOf); month != hijriRamadan {
+		return ""
+	}
+	return ramadanNote
+}
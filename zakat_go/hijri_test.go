@@ -0,0 +1,40 @@
+package zakat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHijriDate(t *testing.T) {
+	tests := []struct {
+		date             time.Time
+		year, month, day int
+	}{
+		{time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), 1446, 9, 15},
+		{time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), 1446, 1, 1},
+		// The epoch, 16 July 622 Julian, is 19 July in the proleptic Gregorian calendar.
+		{time.Date(622, 7, 19, 0, 0, 0, 0, time.UTC), 1, 1, 1},
+	}
+	for _, tt := range tests {
+		y, m, d := hijriDate(tt.date)
+		if y != tt.year || m != tt.month || d != tt.day {
+			t.Errorf("%s: expected %d-%d-%d, got %d-%d-%d", tt.date.Format(time.DateOnly), tt.year, tt.month, tt.day, y, m, d)
+		}
+	}
+}
+
+func TestTimingNote(t *testing.T) {
+	payable := ZakatResult{IsPayable: true, ZakatDue: "250"}
+	inRamadan := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	outside := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if note := payable.TimingNote(inRamadan); note == "" {
+		t.Error("expected a note during Ramadan")
+	}
+	if note := payable.TimingNote(outside); note != "" {
+		t.Errorf("expected no note outside Ramadan, got %q", note)
+	}
+	if note := (ZakatResult{ZakatDue: "0"}).TimingNote(inRamadan); note != "" {
+		t.Errorf("expected no note when nothing is due, got %q", note)
+	}
+}
@@ -0,0 +1,134 @@
+package zakat
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Madhab is an Islamic school of jurisprudence.
+type Madhab string
+
+const (
+	// MadhabHanafi uses the lower of the two nisab; jewelry is zakatable.
+	MadhabHanafi Madhab = "hanafi"
+	// MadhabShafi uses the gold nisab; personal jewelry is exempt.
+	MadhabShafi Madhab = "shafi"
+	// MadhabMaliki uses the gold nisab; personal jewelry is exempt.
+	MadhabMaliki Madhab = "maliki"
+	// MadhabHanbali uses the lower of the two nisab; personal jewelry is exempt.
+	MadhabHanbali Madhab = "hanbali"
+)
+
+// NisabBasis selects which metal the monetary nisab is derived from.
+type NisabBasis string
+
+const (
+	// NisabBasisGold uses 85g x gold price.
+	NisabBasisGold NisabBasis = "gold"
+	// NisabBasisSilver uses 595g x silver price.
+	NisabBasisSilver NisabBasis = "silver"
+	// NisabBasisLowerOfTwo uses the lower of the gold and silver nisab,
+	// the most beneficial for the poor (anfa' lil-fuqara).
+	NisabBasisLowerOfTwo NisabBasis = "lower_of_two"
+)
+
+// livestockTableStandard names the single livestock table the core applies,
+// from the letter of Abu Bakr narrated by Anas (Sahih al-Bukhari 1454).
+const livestockTableStandard = "standard"
+
+// zakatRules are the madhab-specific positions applied by the calculators.
+type zakatRules struct {
+	madhab         Madhab
+	nisabBasis     NisabBasis
+	jewelryExempt  bool
+	tradeGoodsRate decimal.Decimal
+}
+
+// rulesFor returns the rules for a madhab. An empty madhab means Hanafi.
+func rulesFor(madhab Madhab) (zakatRules, error) {
+	switch strings.ToLower(string(madhab)) {
+	case "", "hanafi":
+		// Hanafi views jewelry as growing wealth (amwal namiya).
+		return zakatRules{madhab: MadhabHanafi, nisabBasis: NisabBasisLowerOfTwo, jewelryExempt: false, tradeGoodsRate: tradeGoodsRate}, nil
+	case "shafi", "shafii", "shafi'i":
+		return zakatRules{madhab: MadhabShafi, nisabBasis: NisabBasisGold, jewelryExempt: true, tradeGoodsRate: tradeGoodsRate}, nil
+	case "maliki":
+		return zakatRules{madhab: MadhabMaliki, nisabBasis: NisabBasisGold, jewelryExempt: true, tradeGoodsRate: tradeGoodsRate}, nil
+	case "hanbali":
+		return zakatRules{madhab: MadhabHanbali, nisabBasis: NisabBasisLowerOfTwo, jewelryExempt: true, tradeGoodsRate: tradeGoodsRate}, nil
+	default:
+		return zakatRules{}, fieldError(ErrInvalidMadhab, "madhab", string(madhab))
+	}
+}
+
+// MadhabInfo describes the zakat positions a madhab applies in this package.
+type MadhabInfo struct {
+	// Madhab - canonical madhab name
+	Madhab Madhab
+	// JewelryExempt - whether personal-use jewelry is exempt (huliyy al-mubah)
+	JewelryExempt bool
+	// NisabBasis - the metal the monetary nisab is derived from
+	NisabBasis NisabBasis
+	// TradeGoodsRate - rate applied to monetary wealth (string for precision)
+	TradeGoodsRate string
+	// LivestockTable - the livestock nisab table variant in use
+	LivestockTable string
+}
+
+// MadhabRules returns the positions the calculators apply for a madhab, read
+// from the same rules table the calculators use. Names are matched
+// case-insensitively; an unknown madhab returns a MadhabInfo with only
+// Madhab set.
+func MadhabRules(m Madhab) MadhabInfo {
+	rules, err := rulesFor(m)
+	if err != nil {
+		return MadhabInfo{Madhab: m}
+	}
+	return rules.info()
+}
+
+// info describes the rules as a MadhabInfo.
+func (rules zakatRules) info() MadhabInfo {
+	return MadhabInfo{
+		Madhab:         rules.madhab,
+		JewelryExempt:  rules.jewelryExempt,
+		NisabBasis:     rules.nisabBasis,
+		TradeGoodsRate: rules.tradeGoodsRate.String(),
+		LivestockTable: livestockTableStandard,
+	}
+}
+
+// madhabs lists the supported madhabs in display order.
+var madhabs = []Madhab{MadhabHanafi, MadhabShafi, MadhabMaliki, MadhabHanbali}
+
+// CompareMadhabs calculates input under each supported madhab, keeping the
+// rest of config unchanged. input is any value accepted by Recompute.
+func CompareMadhabs(input any, config Config) (map[Madhab]ZakatResult, error) {
+	results := make(map[Madhab]ZakatResult, len(madhabs))
+	for _, m := range madhabs {
+		result, err := calculateInput(input, config.WithMadhabType(m))
+		if err != nil {
+			return nil, err
+		}
+		results[m] = result
+	}
+	return results, nil
+}
+
+// rules returns the config's madhab rules with its NisabBasis override, if
+// any, applied.
+func (c Config) rules() (zakatRules, error) {
+	rules, err := rulesFor(c.Madhab)
+	if err != nil {
+		return zakatRules{}, err
+	}
+	switch c.NisabBasis {
+	case "":
+	case NisabBasisGold, NisabBasisSilver, NisabBasisLowerOfTwo:
+		rules.nisabBasis = c.NisabBasis
+	default:
+		return zakatRules{}, fieldError(ErrInvalidOption, "nisab_basis", string(c.NisabBasis))
+	}
+	return rules, nil
+}
@@ -0,0 +1,50 @@
+package zakat
+
+import "testing"
+
+func TestMadhabRulesHanafiVsShafi(t *testing.T) {
+	hanafi := MadhabRules(MadhabHanafi)
+	shafi := MadhabRules(MadhabShafi)
+
+	if hanafi.JewelryExempt {
+		t.Errorf("Hanafi should treat jewelry as zakatable")
+	}
+	if !shafi.JewelryExempt {
+		t.Errorf("Shafi should exempt personal jewelry")
+	}
+	if hanafi.NisabBasis != NisabBasisLowerOfTwo {
+		t.Errorf("Hanafi nisab basis: got %s, want %s", hanafi.NisabBasis, NisabBasisLowerOfTwo)
+	}
+	if shafi.NisabBasis != NisabBasisGold {
+		t.Errorf("Shafi nisab basis: got %s, want %s", shafi.NisabBasis, NisabBasisGold)
+	}
+	if hanafi.TradeGoodsRate != "0.025" || shafi.TradeGoodsRate != "0.025" {
+		t.Errorf("trade goods rate should be 0.025 for both, got %s and %s", hanafi.TradeGoodsRate, shafi.TradeGoodsRate)
+	}
+	if hanafi.LivestockTable != shafi.LivestockTable {
+		t.Errorf("livestock table should not differ: %s vs %s", hanafi.LivestockTable, shafi.LivestockTable)
+	}
+}
+
+func TestMadhabRulesMatchCalculators(t *testing.T) {
+	input := GoldInput{WeightGrams: "100", Purity: "24", Usage: "PersonalUse", HawlSatisfied: true}
+	for _, m := range []Madhab{MadhabHanafi, MadhabShafi, MadhabMaliki, MadhabHanbali} {
+		result, err := CalculateGold(input, NewConfig("100", "1").WithMadhabType(m))
+		if err != nil {
+			t.Fatalf("%s: calculation failed: %v", m, err)
+		}
+		if exempt := !result.IsPayable; exempt != MadhabRules(m).JewelryExempt {
+			t.Errorf("%s: calculator exemption %v disagrees with MadhabRules", m, exempt)
+		}
+	}
+}
+
+func TestMadhabRulesAliasesAndUnknown(t *testing.T) {
+	if got := MadhabRules("Shafi'i").Madhab; got != MadhabShafi {
+		t.Errorf("alias should resolve to %s, got %s", MadhabShafi, got)
+	}
+	unknown := MadhabRules("zahiri")
+	if unknown.Madhab != "zahiri" || unknown.NisabBasis != "" {
+		t.Errorf("unknown madhab should only carry its name, got %+v", unknown)
+	}
+}
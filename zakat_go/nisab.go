@@ -0,0 +1,145 @@
+package zakat
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// NisabUnit selects the unit the metal nisab weights are stated in.
+type NisabUnit string
+
+const (
+	// NisabUnitGrams uses the gram figures: 85 g of gold and 595 g of
+	// silver. This is the default.
+	NisabUnitGrams NisabUnit = "grams"
+	// NisabUnitTola uses the figures cited in South Asia, 7.5 tola of gold
+	// and 52.5 tola of silver at 11.664 g per tola (87.48 g and 612.36 g),
+	// and reports the nisab in tolas. They differ from the gram figures
+	// because they rest on a different estimate of the dinar and dirham.
+	NisabUnitTola NisabUnit = "tola"
+)
+
+var (
+	// gramsPerTola is the weight of one tola.
+	gramsPerTola = decimal.RequireFromString("11.664")
+	// goldNisabTola and silverNisabTola are the nisab weights in tolas.
+	goldNisabTola   = decimal.RequireFromString("7.5")
+	silverNisabTola = decimal.RequireFromString("52.5")
+)
+
+func (u NisabUnit) validate() error {
+	switch u {
+	case "", NisabUnitGrams, NisabUnitTola:
+		return nil
+	default:
+		return fieldError(ErrInvalidOption, "nisab_unit", string(u))
+	}
+}
+
+// nisabGrams returns the gold and silver nisab weights in grams for the
+// configured NisabUnit.
+func (c Config) nisabGrams() (gold, silver decimal.Decimal) {
+	if c.NisabUnit == NisabUnitTola {
+		return goldNisabTola.Mul(gramsPerTola), silverNisabTola.Mul(gramsPerTola)
+	}
+	return goldNisabGrams, silverNisabGrams
+}
+
+// toTola converts a weight in grams to tolas, to four places.
+func toTola(grams decimal.Decimal) decimal.Decimal {
+	return grams.DivRound(gramsPerTola, 4)
+}
+
+// NisabResolver determines the monetary nisab, decoupling it from the
+// built-in gram x price math. An organization can plug in its own, for
+// example one that queries a fatwa council's published nisab.
+//
+// Nisab returns the nisab value (a decimal string), the metal it is based on
+// and its weight in grams, both informational.
+type NisabResolver interface {
+	Nisab(ctx context.Context, config Config) (value, metal, grams string, err error)
+}
+
+// PriceNisabResolver is the built-in resolver: 85g of gold or 595g of
+// silver at the config prices (or their tola equivalents, see NisabUnit),
+// chosen by the madhab's nisab basis. It is used when Config.NisabResolver
+// is nil.
+type PriceNisabResolver struct{}
+
+// Nisab implements NisabResolver.
+func (PriceNisabResolver) Nisab(_ context.Context, config Config) (value, metal, grams string, err error) {
+	rules, err := config.rules()
+	if err != nil {
+		return "", "", "", err
+	}
+	nisab, metal, weight, err := priceNisab(config, rules)
+	if err != nil {
+		return "", "", "", err
+	}
+	return nisab.String(), metal, weight.String(), nil
+}
+
+// resolveNisab calls the config's resolver and parses its value.
+func resolveNisab(ctx context.Context, config Config) (decimal.Decimal, error) {
+	value, _, _, err := config.NisabResolver.Nisab(ctx, config)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return parseAmount("nisab", value)
+}
+
+// contextNisabResolver binds a context to a resolver, so resolvers called
+// from the context-aware functions see the caller's context.
+type contextNisabResolver struct {
+	ctx      context.Context
+	resolver NisabResolver
+}
+
+func (r contextNisabResolver) Nisab(_ context.Context, config Config) (value, metal, grams string, err error) {
+	return r.resolver.Nisab(r.ctx, config)
+}
+
+// withContext returns config with its resolver, if any, bound to ctx.
+func (c Config) withContext(ctx context.Context) Config {
+	if c.NisabResolver != nil && ctx != nil {
+		c.NisabResolver = contextNisabResolver{ctx: ctx, resolver: c.NisabResolver}
+	}
+	return c
+}
+
+// NisabRounding selects how the monetary nisab is rounded to whole currency
+// units before the payability test.
+type NisabRounding string
+
+const (
+	// RoundNisabNone compares against the exact nisab. This is the default.
+	RoundNisabNone NisabRounding = ""
+	// RoundNisabDown rounds the nisab down, the protective choice for the
+	// poor: a holding just under the exact nisab may become payable.
+	RoundNisabDown NisabRounding = "down"
+	// RoundNisabUp rounds the nisab up, the protective choice for the
+	// payer: zakat is only levied once the holding clearly meets it.
+	RoundNisabUp NisabRounding = "up"
+)
+
+// apply rounds nisab in direction r.
+func (r NisabRounding) apply(nisab decimal.Decimal) decimal.Decimal {
+	switch r {
+	case RoundNisabDown:
+		return nisab.Floor()
+	case RoundNisabUp:
+		return nisab.Ceil()
+	default:
+		return nisab
+	}
+}
+
+func (r NisabRounding) validate() error {
+	switch r {
+	case RoundNisabNone, RoundNisabDown, RoundNisabUp:
+		return nil
+	default:
+		return fieldError(ErrInvalidOption, "nisab_rounding_direction", string(r))
+	}
+}
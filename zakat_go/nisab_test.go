@@ -0,0 +1,156 @@
+package zakat
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fixedNisab is a resolver returning a published nisab.
+type fixedNisab struct {
+	value string
+	ctx   *context.Context
+}
+
+func (f fixedNisab) Nisab(ctx context.Context, _ Config) (value, metal, grams string, err error) {
+	if f.ctx != nil {
+		*f.ctx = ctx
+	}
+	return f.value, "gold", "85", nil
+}
+
+func TestCustomNisabResolver(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.NisabResolver = fixedNisab{value: "12000"}
+
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NisabThreshold, "12000", "nisab should come from the resolver")
+	if result.IsPayable {
+		t.Errorf("10000 is below the resolved nisab of 12000")
+	}
+}
+
+func TestCustomNisabResolverSeesContext(t *testing.T) {
+	var seen context.Context
+	config := NewConfig("100", "1")
+	config.NisabResolver = fixedNisab{value: "500", ctx: &seen}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	result, err := CalculateContext(ctx, CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if id, _ := RequestIDFromContext(seen); id != "req-1" {
+		t.Errorf("resolver should receive the caller's context, got request ID %q", id)
+	}
+	if _, ok := result.ConfigSnapshot.NisabResolver.(fixedNisab); !ok {
+		t.Errorf("snapshot should keep the caller's resolver, got %T", result.ConfigSnapshot.NisabResolver)
+	}
+}
+
+func TestPriceNisabResolverDefault(t *testing.T) {
+	value, metal, grams, err := PriceNisabResolver{}.Nisab(context.Background(), NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("Nisab failed: %v", err)
+	}
+	if metal != "silver" || grams != "595" {
+		t.Errorf("Hanafi lower-of-two at these prices is silver 595g, got %s %sg", metal, grams)
+	}
+	assertDecimalEqual(t, value, "595", "nisab value mismatch")
+}
+
+func TestNisabResolverError(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.NisabResolver = fixedNisab{value: "abc"}
+	if _, err := CalculateCash(CashInput{CashOnHand: "10000"}, config); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal for a bad resolved nisab, got %v", err)
+	}
+}
+
+func TestNisabRoundingDirection(t *testing.T) {
+	// Silver at 1.0001/g puts the nisab at 595.0595.
+	tests := []struct {
+		cash      string
+		direction NisabRounding
+		payable   bool
+		nisab     string
+	}{
+		{"595.03", RoundNisabNone, false, "595.0595"},
+		{"595.03", RoundNisabDown, true, "595"},
+		{"595.5", RoundNisabNone, true, "595.0595"},
+		{"595.5", RoundNisabUp, false, "596"},
+	}
+	for _, tt := range tests {
+		config := NewConfig("100", "1.0001")
+		config.NisabRoundingDirection = tt.direction
+		result, err := CalculateCash(CashInput{CashOnHand: tt.cash, HawlSatisfied: true}, config)
+		if err != nil {
+			t.Fatalf("cash %s, %q: %v", tt.cash, tt.direction, err)
+		}
+		if result.IsPayable != tt.payable {
+			t.Errorf("cash %s, %q: expected payable=%v", tt.cash, tt.direction, tt.payable)
+		}
+		assertDecimalEqual(t, result.NisabThreshold, tt.nisab, "nisab threshold mismatch")
+	}
+
+	config := NewConfig("100", "1")
+	config.NisabRoundingDirection = "sideways"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestNisabUnitTola(t *testing.T) {
+	grams := NewConfig("100", "1")
+	tola := NewConfig("100", "1")
+	tola.NisabUnit = NisabUnitTola
+
+	// 7.5 tola x 11.664 g = 87.48 g of gold; 52.5 tola = 612.36 g of silver.
+	gold, err := CalculateGold(GoldInput{WeightGrams: "87.48", HawlSatisfied: true}, tola)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, gold.NisabThreshold, "8748", "tola gold nisab should equal 87.48 g")
+	if !gold.IsPayable {
+		t.Error("87.48 g meets the 7.5 tola nisab")
+	}
+	var reported string
+	for _, line := range gold.Breakdown {
+		if line.Key == "step-nisab-tola" {
+			reported = line.Amount
+		}
+	}
+	assertDecimalEqual(t, reported, "7.5", "nisab should be reported in tolas")
+
+	byGrams, err := CalculateGold(GoldInput{WeightGrams: "87.48", HawlSatisfied: true}, grams)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, byGrams.NisabThreshold, "8500", "gram gold nisab should stay 85 g")
+	if gold.ZakatDue != byGrams.ZakatDue {
+		t.Errorf("a holding above both nisabs owes the same: %s vs %s", gold.ZakatDue, byGrams.ZakatDue)
+	}
+
+	cash, err := CalculateCash(CashInput{CashOnHand: "600", HawlSatisfied: true}, tola)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, cash.NisabThreshold, "612.36", "tola silver nisab should equal 612.36 g")
+	if cash.IsPayable {
+		t.Error("600 is below the 52.5 tola silver nisab")
+	}
+
+	explanation, err := ExplainCalculation(CashInput{CashOnHand: "600", HawlSatisfied: true}, tola)
+	if err != nil {
+		t.Fatalf("explain failed: %v", err)
+	}
+	assertDecimalEqual(t, explanation.NisabTola, "52.5", "explanation should report the tola nisab")
+
+	tola.NisabUnit = "mithqal"
+	if _, err := tola.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package zakat
+
+// Option sets an optional Config field in NewConfigWith.
+type Option func(*Config)
+
+// NewConfigWith creates a Config like NewConfig and applies opts in order.
+// Options only set the exported fields, so a config built with options
+// equals the same config written as a struct literal.
+//
+//	config := zakat.NewConfigWith("75.50", "0.85",
+//	    zakat.WithMadhabOpt(zakat.MadhabShafi),
+//	    zakat.WithRoundingOpt(zakat.RoundUp),
+//	)
+func NewConfigWith(goldPrice, silverPrice string, opts ...Option) Config {
+	config := NewConfig(goldPrice, silverPrice)
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithMadhabOpt sets Config.Madhab.
+func WithMadhabOpt(madhab Madhab) Option {
+	return func(c *Config) { c.Madhab = madhab }
+}
+
+// WithNisabBasisOpt sets Config.NisabBasis.
+func WithNisabBasisOpt(basis NisabBasis) Option {
+	return func(c *Config) { c.NisabBasis = basis }
+}
+
+// WithRoundingOpt sets Config.PaymentRounding.
+func WithRoundingOpt(mode Rounding) Option {
+	return func(c *Config) { c.PaymentRounding = mode }
+}
+
+// WithPriceBasisOpt sets Config.MetalPriceBasis.
+func WithPriceBasisOpt(basis MetalPriceBasis) Option {
+	return func(c *Config) { c.MetalPriceBasis = basis }
+}
+
+// WithNisabResolverOpt sets Config.NisabResolver.
+func WithNisabResolverOpt(resolver NisabResolver) Option {
+	return func(c *Config) { c.NisabResolver = resolver }
+}
@@ -0,0 +1,44 @@
+package zakat
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewConfigWithMatchesStructForm(t *testing.T) {
+	got := NewConfigWith("100", "1",
+		WithMadhabOpt(MadhabShafi),
+		WithNisabBasisOpt(NisabBasisSilver),
+		WithRoundingOpt(RoundUp),
+		WithPriceBasisOpt(PriceBasisMid),
+	)
+	want := Config{
+		GoldPricePerGram:   "100",
+		SilverPricePerGram: "1",
+		Madhab:             MadhabShafi,
+		NisabBasis:         NisabBasisSilver,
+		PaymentRounding:    RoundUp,
+		MetalPriceBasis:    PriceBasisMid,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewConfigWith = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(NewConfigWith("100", "1"), NewConfig("100", "1")) {
+		t.Errorf("NewConfigWith without options should equal NewConfig")
+	}
+}
+
+func TestNisabBasisOverride(t *testing.T) {
+	config := NewConfigWith("100", "1", WithMadhabOpt(MadhabShafi), WithNisabBasisOpt(NisabBasisSilver))
+	result, err := CalculateCash(CashInput{CashOnHand: "1000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NisabThreshold, "595", "override should use the silver nisab")
+
+	config.NisabBasis = "platinum"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown nisab basis, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package zakat
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// ParsePercent parses a rate or fraction as typed into a UI and normalizes it
+// to a fraction. The disambiguation rules are:
+//
+//   - a trailing "%" always means percent: "2.5%" is 0.025;
+//   - a bare number at or below 1 is already a fraction: "0.025" is 0.025,
+//     and "1" is 100%;
+//   - a bare number above 1 is a percent: "2.5" is 0.025.
+//
+// Use the explicit "%" form when a percent of 1 or less is meant ("0.5%").
+// Negative values are rejected with ErrNegativeValue.
+func ParsePercent(s string) (decimal.Decimal, error) {
+	return parsePercentField("percent", s)
+}
+
+func parsePercentField(field, s string) (decimal.Decimal, error) {
+	d, isPercent, err := parsePercentNumber(field, s)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if isPercent || d.GreaterThan(decimal.NewFromInt(1)) {
+		return d.Div(hundred), nil
+	}
+	return d, nil
+}
+
+// parsePercentNumber parses s with an optional trailing "%", reporting
+// whether it was present.
+func parsePercentNumber(field, s string) (decimal.Decimal, bool, error) {
+	number, isPercent := strings.CutSuffix(strings.TrimSpace(s), "%")
+	d, err := decimal.NewFromString(strings.TrimSpace(number))
+	if err != nil {
+		return decimal.Zero, false, fieldError(ErrInvalidDecimal, field, s)
+	}
+	if d.IsNegative() {
+		return decimal.Zero, false, fieldError(ErrNegativeValue, field, s)
+	}
+	return d, isPercent, nil
+}
+
+// parseFraction parses an optional fraction field: a bare number from "0"
+// to "1", or a percent with a trailing "%". Unlike ParsePercent, a bare
+// number above 1 is rejected with ErrInvalidFraction rather than read as a
+// percent, so a mistyped "1.5" is not taken as 1.5%. An empty string is
+// zero.
+func parseFraction(field, s string) (decimal.Decimal, error) {
+	if strings.TrimSpace(s) == "" {
+		return decimal.Zero, nil
+	}
+	return parseFractionField(field, s)
+}
+
+func parseFractionField(field, s string) (decimal.Decimal, error) {
+	d, isPercent, err := parsePercentNumber(field, s)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if isPercent {
+		return d.Div(hundred), nil
+	}
+	if d.GreaterThan(decimal.NewFromInt(1)) {
+		return decimal.Zero, fieldError(ErrInvalidFraction, field, s)
+	}
+	return d, nil
+}
@@ -0,0 +1,58 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePercentForms(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2.5%", "0.025"},
+		{" 2.5 % ", "0.025"},
+		{"2.5", "0.025"},
+		{"0.025", "0.025"},
+		{"0.5%", "0.005"},
+		{"1", "1"},
+		{"100%", "1"},
+		{"50", "0.5"},
+		{"0", "0"},
+	}
+	for _, tt := range tests {
+		got, err := ParsePercent(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.in, err)
+			continue
+		}
+		assertDecimalEqual(t, got.String(), tt.want, "ParsePercent("+tt.in+")")
+	}
+}
+
+func TestParsePercentInvalid(t *testing.T) {
+	for _, in := range []string{"", "%", "abc", "2.5%%"} {
+		if _, err := ParsePercent(in); !errors.Is(err, ErrInvalidDecimal) {
+			t.Errorf("%q: expected ErrInvalidDecimal, got %v", in, err)
+		}
+	}
+	if _, err := ParsePercent("-2.5%"); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+}
+
+func TestParseFractionRejectsBareNumberAboveOne(t *testing.T) {
+	for _, in := range []string{"1.5", "50", "100"} {
+		if _, err := parseFraction("fraction", in); !errors.Is(err, ErrInvalidFraction) {
+			t.Errorf("%q: expected ErrInvalidFraction, got %v", in, err)
+		}
+	}
+	for in, want := range map[string]string{"0.5": "0.5", "1": "1", "50%": "0.5", "": "0"} {
+		got, err := parseFraction("fraction", in)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", in, err)
+			continue
+		}
+		assertDecimalEqual(t, got.String(), want, "parseFraction("+in+")")
+	}
+}
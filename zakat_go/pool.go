@@ -0,0 +1,51 @@
+package zakat
+
+// NisabPool names a group of assets that share one nisab test.
+//
+// Gold, silver, cash and trade goods share the monetary nisab and may be
+// joined (dam' al-amwal). Produce and livestock have their own physical
+// nisab (5 awsuq, head counts) and are never pooled with monetary wealth or
+// with each other.
+type NisabPool string
+
+const (
+	// NisabPoolMonetary is the pooled monetary nisab.
+	NisabPoolMonetary NisabPool = "monetary"
+	// NisabPoolAgriculture is the produce nisab of 5 awsuq.
+	NisabPoolAgriculture NisabPool = "agriculture"
+	// NisabPoolLivestock is the livestock nisab by head count.
+	NisabPoolLivestock NisabPool = "livestock"
+)
+
+// NisabPoolOf returns the nisab pool of an asset type. Unknown types are
+// treated as monetary. Produce stored for sale under
+// Config.StoredProduceAsTradeGoods is zakated as trade goods and pooled as
+// monetary in portfolios.
+func NisabPoolOf(assetType string) NisabPool {
+	switch assetType {
+	case AssetTypeAgriculture:
+		return NisabPoolAgriculture
+	case "livestock":
+		return NisabPoolLivestock
+	default:
+		return NisabPoolMonetary
+	}
+}
+
+// tradeGoodsProduceKey marks the breakdown of produce valued as trade goods
+// under Config.StoredProduceAsTradeGoods.
+const tradeGoodsProduceKey = "info-stored-as-trade-goods"
+
+// resultPool returns the nisab pool of a calculated result. It is
+// NisabPoolOf its asset type, except that produce valued as trade goods
+// joins the monetary pool.
+func resultPool(result ZakatResult) NisabPool {
+	if result.AssetType == AssetTypeAgriculture {
+		for _, line := range result.Breakdown {
+			if line.Key == tradeGoodsProduceKey {
+				return NisabPoolMonetary
+			}
+		}
+	}
+	return NisabPoolOf(result.AssetType)
+}
@@ -0,0 +1,67 @@
+package zakat
+
+import "testing"
+
+func TestPortfolioKeepsAgricultureInSeparateNisabPool(t *testing.T) {
+	config := NewConfig("100", "1")
+	input := PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "400", HawlSatisfied: true}},
+		// 300 kg is below the 653 kg produce nisab, though its value of 600
+		// would clear the monetary nisab of 595 if wrongly pooled.
+		Agriculture: []AgricultureInput{{HarvestWeightKg: "300", PricePerKg: "2"}},
+	}
+	result, err := CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("neither pool clears its own nisab; nothing should be payable")
+	}
+	assertDecimalEqual(t, result.NetAssets, "400", "only monetary assets are pooled")
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+}
+
+func TestPortfolioAddsSeparatePoolDue(t *testing.T) {
+	config := NewConfig("100", "1")
+	result, err := Calc(config).
+		Cash(CashInput{CashOnHand: "10000", HawlSatisfied: true}).
+		Agriculture(AgricultureInput{HarvestWeightKg: "1000", PricePerKg: "1"}).
+		Portfolio()
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "10000", "harvest value is not pooled with cash")
+	// 250 on the cash pool plus 10% ushr of 1000 on the harvest.
+	assertDecimalEqual(t, result.ZakatDue, "350", "zakat_due mismatch")
+}
+
+func TestNisabPoolOf(t *testing.T) {
+	cases := map[string]NisabPool{
+		AssetTypeCash:        NisabPoolMonetary,
+		AssetTypeGold:        NisabPoolMonetary,
+		AssetTypeAgriculture: NisabPoolAgriculture,
+		"livestock":          NisabPoolLivestock,
+	}
+	for assetType, want := range cases {
+		if got := NisabPoolOf(assetType); got != want {
+			t.Errorf("NisabPoolOf(%q) = %q, want %q", assetType, got, want)
+		}
+	}
+}
+
+func TestPortfolioPoolsProduceStoredAsTradeGoods(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.StoredProduceAsTradeGoods = true
+	input := PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "400", HawlSatisfied: true}},
+		// Valued as trade goods, the stored produce joins the cash: 400 +
+		// 600 clears the monetary nisab of 595.
+		Agriculture: []AgricultureInput{{HarvestWeightKg: "300", PricePerKg: "2", HeldForSale: true, HawlSatisfied: true}},
+	}
+	result, err := CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "1000", "stored produce should join the monetary pool")
+	assertDecimalEqual(t, result.ZakatDue, "25", "zakat_due mismatch")
+}
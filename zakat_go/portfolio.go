@@ -0,0 +1,479 @@
+package zakat
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PortfolioInput groups the monetary assets of one owner.
+//
+// Gold, silver, cash and trade goods are of a single genus (thamaniyyah), so
+// their net values are joined for one nisab test (dam' al-amwal). Produce
+// keeps its own nisab (see NisabPool) and is never joined with them.
+type PortfolioInput struct {
+	Business    []BusinessInput
+	Gold        []GoldInput
+	Silver      []SilverInput
+	Cash        []CashInput
+	Agriculture []AgricultureInput
+}
+
+// PortfolioResult holds the pooled result of a portfolio calculation.
+// The embedded ZakatResult carries the pooled totals; Components holds the
+// individual result of each asset in input order.
+type PortfolioResult struct {
+	ZakatResult
+	// Components - the individual result of each asset
+	Components []ZakatResult
+}
+
+// PortfolioHawlPolicy decides the hawl of a portfolio whose components were
+// acquired at different times.
+//
+// The inputs carry no acquisition dates, so the policies other than
+// HawlShared combine the components' HawlSatisfied flags; callers derive each
+// flag from the component's own acquisition date, for example with
+// HawlComplete or HawlCompleteAtPeriodEnd. HawlShared alone works from a
+// date, Config.PortfolioHawlAnchor.
+type PortfolioHawlPolicy string
+
+const (
+	// HawlPerComponent applies each component's own HawlSatisfied: a
+	// component still in its first year adds nothing to the pool. This is
+	// the default.
+	HawlPerComponent PortfolioHawlPolicy = ""
+	// HawlStrictest makes the whole portfolio payable only once every
+	// monetary component has HawlSatisfied set; until then nothing is due.
+	HawlStrictest PortfolioHawlPolicy = "strictest"
+	// HawlEarliest lets the oldest component's hawl govern the pool: once
+	// any component has HawlSatisfied set, later acquisitions are zakated
+	// with it (the Hanafi view that additions join the original's hawl).
+	HawlEarliest PortfolioHawlPolicy = "earliest"
+	// HawlShared uses one hawl for the whole portfolio, starting at
+	// Config.PortfolioHawlAnchor and evaluated at Config.PeriodEnd; the
+	// components' own flags are ignored.
+	HawlShared PortfolioHawlPolicy = "shared"
+)
+
+func (p PortfolioHawlPolicy) validate() error {
+	switch p {
+	case HawlPerComponent, HawlStrictest, HawlEarliest, HawlShared:
+		return nil
+	default:
+		return fieldError(ErrInvalidOption, "portfolio_hawl_policy", string(p))
+	}
+}
+
+// portfolioHawl returns the hawl decision imposed on the monetary
+// components by the config's policy, or nil to keep each component's own.
+// It reads the components' HawlSatisfied flags, not any dates.
+func portfolioHawl(input PortfolioInput, config Config) (*bool, string, error) {
+	var flags []bool
+	for _, in := range input.Business {
+		flags = append(flags, in.HawlSatisfied)
+	}
+	for _, in := range input.Gold {
+		flags = append(flags, in.HawlSatisfied)
+	}
+	for _, in := range input.Silver {
+		flags = append(flags, in.HawlSatisfied)
+	}
+	for _, in := range input.Cash {
+		flags = append(flags, in.HawlSatisfied)
+	}
+	return policyHawl(flags, config)
+}
+
+// policyHawl applies the config's PortfolioHawlPolicy to the HawlSatisfied
+// flags of the monetary components, as the caller computed them. Only
+// HawlShared looks at a date, the configured anchor.
+func policyHawl(flags []bool, config Config) (*bool, string, error) {
+	if err := config.PortfolioHawlPolicy.validate(); err != nil {
+		return nil, "", err
+	}
+	var satisfied bool
+	var note string
+	switch config.PortfolioHawlPolicy {
+	case HawlPerComponent:
+		return nil, "", nil
+	case HawlStrictest:
+		satisfied = len(flags) > 0
+		for _, flag := range flags {
+			satisfied = satisfied && flag
+		}
+		note = "Portfolio hawl: payable only once every component has completed its hawl (HawlStrictest)."
+	case HawlEarliest:
+		for _, flag := range flags {
+			satisfied = satisfied || flag
+		}
+		note = "Portfolio hawl: the oldest component's hawl governs later acquisitions (HawlEarliest)."
+	case HawlShared:
+		if config.PortfolioHawlAnchor.IsZero() {
+			return nil, "", fieldError(ErrInconsistentInput, "portfolio_hawl_anchor", "")
+		}
+		var err error
+		if satisfied, err = HawlCompleteAtPeriodEnd(config.PortfolioHawlAnchor, config); err != nil {
+			return nil, "", err
+		}
+		note = fmt.Sprintf("Portfolio hawl: one hawl from %s for all components (HawlShared).", config.PortfolioHawlAnchor.Format(time.DateOnly))
+	}
+	return &satisfied, note, nil
+}
+
+// CalculatePortfolio calculates each asset, then pools their net assets for a
+// single nisab test against the monetary nisab. Config.PortfolioHawlPolicy
+// decides how components acquired at different times share a hawl.
+//
+// Components are calculated in the order Business, Gold, Silver, Cash,
+// Agriculture.
+func CalculatePortfolio(input PortfolioInput, config Config) (PortfolioResult, error) {
+	hawl, note, err := portfolioHawl(input, config)
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	var components []ZakatResult
+	for _, in := range input.Business {
+		if hawl != nil {
+			in.HawlSatisfied = *hawl
+		}
+		result, err := CalculateBusiness(in, config)
+		if err != nil {
+			return PortfolioResult{}, err
+		}
+		components = append(components, result)
+	}
+	for _, in := range input.Gold {
+		if hawl != nil {
+			in.HawlSatisfied = *hawl
+		}
+		result, err := CalculateGold(in, config)
+		if err != nil {
+			return PortfolioResult{}, err
+		}
+		components = append(components, result)
+	}
+	for _, in := range input.Silver {
+		if hawl != nil {
+			in.HawlSatisfied = *hawl
+		}
+		result, err := CalculateSilver(in, config)
+		if err != nil {
+			return PortfolioResult{}, err
+		}
+		components = append(components, result)
+	}
+	for _, in := range input.Cash {
+		if hawl != nil {
+			in.HawlSatisfied = *hawl
+		}
+		result, err := CalculateCash(in, config)
+		if err != nil {
+			return PortfolioResult{}, err
+		}
+		components = append(components, result)
+	}
+	for _, in := range input.Agriculture {
+		result, err := CalculateAgriculture(in, config)
+		if err != nil {
+			return PortfolioResult{}, err
+		}
+		components = append(components, result)
+	}
+	result, err := poolResults(components, config)
+	if err == nil && note != "" {
+		result.Assumptions = append(result.Assumptions, note)
+	}
+	return result, err
+}
+
+// CalculateBusinessesCombined calculates zakat on several businesses of one
+// owner. All are the owner's wealth, so each business's net zakatable
+// assets are calculated on its own (with its own liabilities and hawl) and
+// then pooled for one nisab test and rate, as in CalculatePortfolio. The
+// breakdown lists each business by its input position, including those
+// exempted on their own (such as one still in its first hawl).
+func CalculateBusinessesCombined(inputs []BusinessInput, config Config) (ZakatResult, error) {
+	components := make([]ZakatResult, 0, len(inputs))
+	for _, in := range inputs {
+		result, err := CalculateBusiness(in, config)
+		if err != nil {
+			return ZakatResult{}, err
+		}
+		components = append(components, result)
+	}
+	pooled, err := poolResults(components, config)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	result := pooled.ZakatResult
+	result.AssetType = AssetTypeBusiness
+	// poolResults lists every component once, pooled or exempt, in input
+	// order, so the nth such line is business #n.
+	n := 0
+	for i, line := range result.Breakdown {
+		switch line.Key {
+		case "step-component":
+			n++
+			result.Breakdown[i].Label = fmt.Sprintf("Net Business #%d", n)
+		case "step-component-exempt":
+			n++
+			reason, _ := exemptReason(components[n-1])
+			result.Breakdown[i].Label = fmt.Sprintf("Exempt Business #%d: %s", n, reason)
+		}
+	}
+	return result, nil
+}
+
+// CalculatePortfolioBestBasis calculates the portfolio under both the gold
+// and the silver nisab and returns the result that owes less, with the
+// basis chosen ("gold" or "silver"). Both bases are held by scholars; the
+// lower of the two nisab thresholds at the configured prices is the
+// cautious default and wins ties, so the higher one is only chosen when it
+// actually lowers the due. Config.NisabBasis is ignored. The choice is
+// recorded in the result's assumptions.
+func CalculatePortfolioBestBasis(input PortfolioInput, config Config) (PortfolioResult, string, error) {
+	config.NisabBasis = NisabBasisSilver
+	silver, err := CalculatePortfolio(input, config)
+	if err != nil {
+		return PortfolioResult{}, "", err
+	}
+	config.NisabBasis = NisabBasisGold
+	gold, err := CalculatePortfolio(input, config)
+	if err != nil {
+		return PortfolioResult{}, "", err
+	}
+
+	// Silver's nisab is usually the lower, but not at every price ratio.
+	cautious, cautiousBasis, other, otherBasis := silver, NisabBasisSilver, gold, NisabBasisGold
+	if ToDecimal(gold.NisabThreshold).LessThan(ToDecimal(silver.NisabThreshold)) {
+		cautious, cautiousBasis, other, otherBasis = gold, NisabBasisGold, silver, NisabBasisSilver
+	}
+	if other.ZakatDueDecimal().LessThan(cautious.ZakatDueDecimal()) {
+		other.Assumptions = append(other.Assumptions, fmt.Sprintf(
+			"%s nisab of %s chosen as the more favorable valid basis (due %s, against %s on %s); the lower %s nisab of %s is the cautious default.",
+			basisName(otherBasis), other.NisabThreshold, other.ZakatDue, cautious.ZakatDue, cautiousBasis, cautiousBasis, cautious.NisabThreshold))
+		return other, string(otherBasis), nil
+	}
+	cautious.Assumptions = append(cautious.Assumptions, fmt.Sprintf(
+		"%s nisab of %s kept: the lower nisab is the cautious default, and the %s nisab of %s would not lower the due.",
+		basisName(cautiousBasis), cautious.NisabThreshold, otherBasis, other.NisabThreshold))
+	return cautious, string(cautiousBasis), nil
+}
+
+// basisName capitalizes a nisab basis for the start of a sentence.
+func basisName(basis NisabBasis) string {
+	if basis == NisabBasisGold {
+		return "Gold"
+	}
+	return "Silver"
+}
+
+// poolResults joins the net assets of already-calculated monetary components
+// and applies one nisab test and rate to the total. Components in another
+// NisabPool keep their own nisab test; their zakat due is added to the
+// pooled due but their assets are not pooled; so do gold and silver under
+// Config.MetalNisabInGrams.
+//
+// Exemptions are applied by each component's calculator before pooling: a
+// wholly exempt component (such as personal-use jewelry under an exempting
+// madhab) is listed in the breakdown but adds nothing to the pool, and a
+// partly exempt one contributes only its zakatable share. The components'
+// DeferredAmount, pooled or not, are summed into the result's.
+func poolResults(components []ZakatResult, config Config) (PortfolioResult, error) {
+	rules, err := config.rules()
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+	exemption, err := parseAmount("personal_exemption", config.PersonalExemption)
+	if err != nil {
+		return PortfolioResult{}, err
+	}
+
+	totalAssets := decimal.Zero
+	netAssets := decimal.Zero
+	separateDue := decimal.Zero
+	separatePayable := false
+	deferred := decimal.Zero
+	charity := decimal.Zero
+	// minimum is the pooled balance tested under Config.UseMinimumBalance:
+	// each component's minimum over the hawl where it reports one, else its
+	// net assets.
+	minimum := decimal.Zero
+	hasMinimum := false
+	var breakdown []BreakdownLine
+	var assumptions []string
+	for _, component := range components {
+		deferred = deferred.Add(ToDecimal(component.DeferredAmount))
+		net := ToDecimal(component.NetAssets)
+		if !pooled(component, config) {
+			due := ToDecimal(component.ZakatDue)
+			separateDue = separateDue.Add(due)
+			separatePayable = separatePayable || component.IsPayable
+			breakdown = append(breakdown, amountLine("step-separate-pool", "Zakat on "+component.AssetType+" (own nisab)", due, OpInfo))
+			assumptions = appendUnique(assumptions, component.Assumptions...)
+			continue
+		}
+		// The pool takes the exemption once, so the component's own note on
+		// deducting it does not apply.
+		for _, assumption := range component.Assumptions {
+			if assumption != fmt.Sprintf(personalExemptionNote, exemption) {
+				assumptions = appendUnique(assumptions, assumption)
+			}
+		}
+		if reason, ok := exemptReason(component); ok {
+			breakdown = append(breakdown, infoLine("step-component-exempt", "Exempt "+component.AssetType+": "+reason))
+			continue
+		}
+		totalAssets = totalAssets.Add(ToDecimal(component.TotalAssets))
+		netAssets = netAssets.Add(net)
+		effective := net
+		for _, line := range component.Breakdown {
+			switch line.Key {
+			case "step-charity-intended":
+				charity = charity.Add(ToDecimal(line.Amount))
+			case "step-minimum-balance":
+				effective = ToDecimal(line.Amount)
+				hasMinimum = true
+			}
+		}
+		minimum = minimum.Add(effective)
+		breakdown = append(breakdown, amountLine("step-component", "Net "+component.AssetType, net, OpAdd))
+	}
+
+	isPayable := meetsNisab(netAssets, nisab)
+	minimumBelowNisab := hasMinimum && !meetsNisab(minimum, nisab)
+	if minimumBelowNisab {
+		isPayable = false
+	}
+	zakatDue := decimal.Zero
+	breakdown = append(breakdown, amountLine("step-net-assets", "Pooled Net Assets", netAssets, OpResult))
+	if hasMinimum {
+		breakdown = append(breakdown, amountLine("step-minimum-balance", "Pooled Minimum Balance Over Hawl", minimum, OpCompare))
+	}
+	breakdown = append(breakdown, amountLine("step-nisab-check", "Nisab Threshold", nisab, OpCompare))
+	// The exemption is taken once from the pool, not from each component.
+	base := netAssets
+	if isPayable && exemption.IsPositive() {
+		base = decimal.Max(netAssets.Sub(exemption), decimal.Zero)
+		isPayable = base.IsPositive()
+		breakdown = append(breakdown,
+			amountLine("step-personal-exemption", "Personal Exemption", exemption, OpSubtract),
+			amountLine("step-zakatable-base", "Zakatable Base", base, OpResult),
+		)
+		assumptions = appendUnique(assumptions, fmt.Sprintf(personalExemptionNote, exemption))
+	}
+	if isPayable {
+		zakatDue = base.Mul(rules.tradeGoodsRate)
+		breakdown = append(breakdown, amountLine("step-rate-applied", "Rate Applied", rules.tradeGoodsRate, OpRate))
+		// Zakat-intended charity is credited once against the pooled due;
+		// the components' own credits are not summed.
+		if charity.IsPositive() && config.CharityCountsTowardZakat {
+			credited := decimal.Min(charity, zakatDue)
+			breakdown = append(breakdown,
+				amountLine("step-zakat-obligation", "Zakat Obligation", zakatDue, OpResult),
+				amountLine("step-charity-credited", "Zakat-Intended Charity Given", credited, OpSubtract),
+			)
+			zakatDue = zakatDue.Sub(credited)
+			assumptions = append(assumptions, fmt.Sprintf("Zakat-intended charity of %s credited once against the pooled due (CharityCountsTowardZakat).", charity))
+		}
+		breakdown = append(breakdown, amountLine("status-due", "Zakat Due", zakatDue, OpResult))
+	} else if minimumBelowNisab {
+		breakdown = append(breakdown, infoLine("status-exempt", "Minimum balance below Nisab"))
+	} else {
+		breakdown = append(breakdown, infoLine("status-exempt", "Below Nisab"))
+	}
+	if separateDue.IsPositive() {
+		zakatDue = zakatDue.Add(separateDue)
+		breakdown = append(breakdown, amountLine("status-total-due", "Total Zakat Due", zakatDue, OpResult))
+	}
+
+	var deferredAmount string
+	if deferred.IsPositive() {
+		deferredAmount = deferred.String()
+	}
+	return PortfolioResult{
+		ZakatResult: ZakatResult{
+			AssetType:      "portfolio",
+			IsPayable:      isPayable || separatePayable,
+			ZakatDue:       zakatDue.String(),
+			TotalAssets:    totalAssets.String(),
+			NetAssets:      netAssets.String(),
+			NisabThreshold: nisab.String(),
+			DeferredAmount: deferredAmount,
+			Breakdown:      breakdown,
+			Assumptions:    assumptions,
+			ConfigSnapshot: config,
+		},
+		Components: components,
+	}, nil
+}
+
+// appendUnique appends the values not already in list, keeping their order.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// pooled reports whether a component joins the monetary pool. Under
+// Config.MetalNisabInGrams gold and silver are tested on their own weight,
+// which a value pool cannot express, so they keep their own nisab.
+func pooled(component ZakatResult, config Config) bool {
+	if config.MetalNisabInGrams && (component.AssetType == AssetTypeGold || component.AssetType == AssetTypeSilver) {
+		return false
+	}
+	return resultPool(component) == NisabPoolMonetary
+}
+
+// exemptReason reports whether a component was wholly exempted by its
+// calculator (see exemptResult), and why.
+func exemptReason(component ZakatResult) (string, bool) {
+	if len(component.Breakdown) != 1 || component.Breakdown[0].Key != "status-exempt" || !ToDecimal(component.TotalAssets).IsZero() {
+		return "", false
+	}
+	return component.Breakdown[0].Label, true
+}
+
+// householdDisclaimer accompanies every household advisory.
+const householdDisclaimer = "Advisory only: zakat is assessed on each member individually; pooled household wealth does not create an obligation."
+
+// HouseholdNisabAdvisory reports whether a household's pooled net wealth
+// clears the monetary nisab, for families who manage finances together.
+//
+// It is informational: each member's zakat is still assessed on their own
+// wealth with CalculatePortfolio. The message always carries that
+// disclaimer, or explains why the household could not be assessed.
+func HouseholdNisabAdvisory(members []PortfolioInput, config Config) (bool, string) {
+	rules, err := config.rules()
+	if err != nil {
+		return false, "Household not assessed: " + err.Error()
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return false, "Household not assessed: " + err.Error()
+	}
+
+	pooled := decimal.Zero
+	for _, member := range members {
+		result, err := CalculatePortfolio(member, config)
+		if err != nil {
+			return false, "Household not assessed: " + err.Error()
+		}
+		pooled = pooled.Add(ToDecimal(result.NetAssets))
+	}
+	if meetsNisab(pooled, nisab) {
+		return true, fmt.Sprintf("Pooled household wealth of %s clears the nisab of %s. %s", pooled, nisab, householdDisclaimer)
+	}
+	return false, fmt.Sprintf("Pooled household wealth of %s is below the nisab of %s. %s", pooled, nisab, householdDisclaimer)
+}
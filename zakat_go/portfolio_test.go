@@ -0,0 +1,345 @@
+package zakat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalculatePortfolioPoolsBelowNisabComponents(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := CalculatePortfolio(PortfolioInput{
+		Cash:   []CashInput{{CashOnHand: "3000", HawlSatisfied: true}},
+		Silver: []SilverInput{{WeightGrams: "6000", Purity: "1000", HawlSatisfied: true}},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("pooled portfolio should clear the gold nisab")
+	}
+	assertDecimalEqual(t, result.NetAssets, "9000", "pooled net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "225", "pooled zakat_due mismatch")
+	assertDecimalEqual(t, result.NisabThreshold, "8500", "nisab mismatch")
+}
+
+func TestCalculatePortfolioBelowNisab(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := CalculatePortfolio(PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "3000", HawlSatisfied: true}},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("portfolio below nisab should not be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+}
+
+func TestHouseholdNisabAdvisoryPooledClears(t *testing.T) {
+	config := NewConfig("100", "1")
+	members := []PortfolioInput{
+		{Cash: []CashInput{{CashOnHand: "400", HawlSatisfied: true}}},
+		{Cash: []CashInput{{CashOnHand: "300", HawlSatisfied: true}}},
+	}
+	for i, member := range members {
+		result, err := CalculatePortfolio(member, config)
+		if err != nil {
+			t.Fatalf("member %d: %v", i, err)
+		}
+		if result.IsPayable {
+			t.Fatalf("member %d should be below nisab individually", i)
+		}
+	}
+
+	clears, message := HouseholdNisabAdvisory(members, config)
+	if !clears {
+		t.Errorf("pooled 700 should clear the nisab of 595: %s", message)
+	}
+	if !strings.Contains(message, "individually") {
+		t.Errorf("advisory should carry the individual-assessment disclaimer, got %q", message)
+	}
+}
+
+func TestPortfolioHawlPolicies(t *testing.T) {
+	// Cash held a full year, gold bought two months ago. The policies
+	// combine flags, so each is derived from the acquisition date.
+	periodEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	dated := NewConfig("100", "1")
+	dated.PeriodEnd = periodEnd
+	cashHeld, err := HawlCompleteAtPeriodEnd(periodEnd.AddDate(-1, 0, 0), dated)
+	if err != nil {
+		t.Fatalf("hawl failed: %v", err)
+	}
+	goldHeld, err := HawlCompleteAtPeriodEnd(periodEnd.AddDate(0, -2, 0), dated)
+	if err != nil {
+		t.Fatalf("hawl failed: %v", err)
+	}
+	input := PortfolioInput{
+		Cash: []CashInput{{CashOnHand: "9000", HawlSatisfied: cashHeld}},
+		Gold: []GoldInput{{WeightGrams: "40", HawlSatisfied: goldHeld}},
+	}
+
+	tests := []struct {
+		name   string
+		policy PortfolioHawlPolicy
+		anchor time.Time
+		due    string
+	}{
+		{"per component", HawlPerComponent, time.Time{}, "225"},
+		{"strictest", HawlStrictest, time.Time{}, "0"},
+		{"earliest", HawlEarliest, time.Time{}, "325"},
+		{"shared, anchor a year back", HawlShared, periodEnd.AddDate(-1, 0, 0), "325"},
+		{"shared, anchor a month back", HawlShared, periodEnd.AddDate(0, -1, 0), "0"},
+	}
+	for _, tt := range tests {
+		config := NewConfig("100", "1").WithMadhab("shafi")
+		config.PortfolioHawlPolicy = tt.policy
+		config.PortfolioHawlAnchor = tt.anchor
+		config.PeriodEnd = periodEnd
+		result, err := CalculatePortfolio(input, config)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		assertDecimalEqual(t, result.ZakatDue, tt.due, tt.name+": zakat_due mismatch")
+	}
+
+	config := NewConfig("100", "1")
+	config.PortfolioHawlPolicy = HawlShared
+	if _, err := CalculatePortfolio(input, config); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("shared without an anchor: expected ErrInconsistentInput, got %v", err)
+	}
+	config.PortfolioHawlPolicy = "latest"
+	if _, err := CalculatePortfolio(input, config); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("unknown policy: expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestCalculatePortfolioExemptPersonalGold(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := CalculatePortfolio(PortfolioInput{
+		Gold: []GoldInput{{WeightGrams: "200", Purity: "24", Usage: "PersonalUse", HawlSatisfied: true}},
+		Cash: []CashInput{{CashOnHand: "10000", HawlSatisfied: true}},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "exempt gold should not inflate the pooled assets")
+	assertDecimalEqual(t, result.NetAssets, "10000", "exempt gold should not inflate the pooled base")
+	assertDecimalEqual(t, result.ZakatDue, "250", "only the cash should be zakated")
+	if len(result.Components) != 2 {
+		t.Fatalf("expected both components, got %d", len(result.Components))
+	}
+
+	var listed bool
+	for _, line := range result.Breakdown {
+		if line.Key == "step-component-exempt" && strings.Contains(line.Label, AssetTypeGold) {
+			listed = true
+		}
+	}
+	if !listed {
+		t.Errorf("exempt gold should still appear in the breakdown: %+v", result.Breakdown)
+	}
+}
+
+func TestCalculatePortfolioPartlyExemptGold(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	result, err := CalculatePortfolio(PortfolioInput{
+		Gold: []GoldInput{{WeightGrams: "200", Purity: "24", InvestmentFraction: "0.25", HawlSatisfied: true}},
+		Cash: []CashInput{{CashOnHand: "10000", HawlSatisfied: true}},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "15000", "only the invested quarter of the gold should be pooled")
+	assertDecimalEqual(t, result.ZakatDue, "375", "pooled zakat_due mismatch")
+}
+
+func TestCalculatePortfolioBestBasis(t *testing.T) {
+	config := NewConfig("100", "1")
+	between := PortfolioInput{Cash: []CashInput{{CashOnHand: "3000", HawlSatisfied: true}}}
+	result, basis, err := CalculatePortfolioBestBasis(between, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if basis != string(NisabBasisGold) {
+		t.Errorf("3000 clears the silver nisab only, so gold should be chosen; got %q", basis)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "gold basis zakat_due mismatch")
+	assertDecimalEqual(t, result.NisabThreshold, "8500", "gold nisab mismatch")
+	if n := len(result.Assumptions); n == 0 || !strings.Contains(result.Assumptions[n-1], "cautious default") {
+		t.Errorf("expected the basis note, got %v", result.Assumptions)
+	}
+
+	above := PortfolioInput{Cash: []CashInput{{CashOnHand: "10000", HawlSatisfied: true}}}
+	result, basis, err = CalculatePortfolioBestBasis(above, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if basis != string(NisabBasisSilver) {
+		t.Errorf("with equal dues the silver default should be kept; got %q", basis)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250", "silver basis zakat_due mismatch")
+	assertDecimalEqual(t, result.NisabThreshold, "595", "silver nisab mismatch")
+
+	// At these prices the gold nisab (85) is the lower one, so it is the
+	// default kept on a tie.
+	wealthy := PortfolioInput{Cash: []CashInput{{CashOnHand: "100000", HawlSatisfied: true}}}
+	result, basis, err = CalculatePortfolioBestBasis(wealthy, NewConfig("1", "100"))
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	if basis != string(NisabBasisGold) {
+		t.Errorf("with equal dues the lower gold nisab should be kept; got %q", basis)
+	}
+	assertDecimalEqual(t, result.NisabThreshold, "85", "gold nisab mismatch")
+}
+
+func TestCalculateBusinessesCombined(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	businesses := []BusinessInput{
+		{CashOnHand: "3000", InventoryValue: "2000", Liabilities: "1000", HawlSatisfied: true},
+		{CashOnHand: "2500", InventoryValue: "2500", HawlSatisfied: true},
+	}
+	for i, in := range businesses {
+		alone, err := CalculateBusiness(in, config)
+		if err != nil {
+			t.Fatalf("business %d failed: %v", i, err)
+		}
+		if alone.IsPayable {
+			t.Fatalf("business %d should be below the 8500 nisab on its own", i)
+		}
+	}
+
+	combined, err := CalculateBusinessesCombined(businesses, config)
+	if err != nil {
+		t.Fatalf("combined calculation failed: %v", err)
+	}
+	if !combined.IsPayable {
+		t.Error("the businesses together should clear the nisab")
+	}
+	assertDecimalEqual(t, combined.NetAssets, "9000", "pooled net assets mismatch")
+	assertDecimalEqual(t, combined.ZakatDue, "225", "combined zakat_due mismatch")
+	if combined.AssetType != AssetTypeBusiness {
+		t.Errorf("asset type mismatch: %s", combined.AssetType)
+	}
+
+	var lines []string
+	for _, line := range combined.Breakdown {
+		if line.Key == "step-component" {
+			lines = append(lines, line.Label+"="+line.Amount)
+		}
+	}
+	if want := "Net Business #1=4000,Net Business #2=5000"; strings.Join(lines, ",") != want {
+		t.Errorf("per-business breakdown mismatch: %v", lines)
+	}
+}
+
+func TestCalculateBusinessesCombinedNumbersExemptBusinesses(t *testing.T) {
+	config := NewConfig("100", "1").WithMadhab("shafi")
+	businesses := []BusinessInput{
+		{CashOnHand: "7000", HawlSatisfied: false},
+		{CashOnHand: "3000", InventoryValue: "2000", HawlSatisfied: true},
+		{CashOnHand: "5000", HawlSatisfied: true},
+	}
+	combined, err := CalculateBusinessesCombined(businesses, config)
+	if err != nil {
+		t.Fatalf("combined calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, combined.NetAssets, "10000", "the first-year business should not be pooled")
+
+	var lines []string
+	for _, line := range combined.Breakdown {
+		if line.Key == "step-component" || line.Key == "step-component-exempt" {
+			lines = append(lines, line.Label)
+		}
+	}
+	want := "Exempt Business #1: Hawl (1 lunar year) not met,Net Business #2,Net Business #3"
+	if strings.Join(lines, ",") != want {
+		t.Errorf("per-business breakdown mismatch: %v", lines)
+	}
+}
+
+func TestPortfolioMetalNisabInGrams(t *testing.T) {
+	config := NewConfig("100", "1")
+	input := PortfolioInput{Gold: []GoldInput{{WeightGrams: "80", Purity: "24", HawlSatisfied: true}}}
+
+	byValue, err := CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, byValue.ZakatDue, "200", "80g worth 8000 clears the value nisab")
+
+	config.MetalNisabInGrams = true
+	byWeight, err := CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, byWeight.ZakatDue, "0", "80g is below the 85g weight nisab")
+
+	input.Cash = []CashInput{{CashOnHand: "1000", HawlSatisfied: true}}
+	byWeight, err = CalculatePortfolio(input, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, byWeight.NetAssets, "1000", "gold should not join the value pool")
+	assertDecimalEqual(t, byWeight.ZakatDue, "25", "only the cash is payable")
+}
+
+func TestPortfolioPersonalExemptionNotedOnce(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PersonalExemption = "1000"
+	result, err := CalculatePortfolio(PortfolioInput{
+		Cash: []CashInput{
+			{CashOnHand: "5000", HawlSatisfied: true},
+			{CashOnHand: "7000", HawlSatisfied: true},
+		},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "275", "the exemption is taken once from the pool")
+
+	var notes int
+	for _, assumption := range result.Assumptions {
+		if strings.HasPrefix(assumption, "Personal exemption") {
+			notes++
+		}
+	}
+	if notes != 1 {
+		t.Errorf("expected one personal exemption note, got %d: %v", notes, result.Assumptions)
+	}
+}
+
+func TestPortfolioSumsDeferredAmounts(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PeriodEnd = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	config.FutureReceivablePolicy = FutureReceivableDeferUntilDue
+	business := BusinessInput{
+		CashOnHand: "10000",
+		ReceivableItems: []Receivable{
+			{Debtor: "Toko A", Amount: "2000", DueDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{Debtor: "Toko B", Amount: "4000", DueDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		HawlSatisfied: true,
+	}
+
+	result, err := CalculatePortfolio(PortfolioInput{
+		Business: []BusinessInput{business},
+		Cash:     []CashInput{{CashOnHand: "3000", HawlSatisfied: true}},
+	}, config)
+	if err != nil {
+		t.Fatalf("portfolio failed: %v", err)
+	}
+	assertDecimalEqual(t, result.NetAssets, "15000", "the deferred receivable should stay out of the pool")
+	assertDecimalEqual(t, result.ZakatDue, "375", "pooled zakat_due mismatch")
+	assertDecimalEqual(t, result.DeferredAmount, "4000", "portfolio deferred amount mismatch")
+
+	combined, err := CalculateBusinessesCombined([]BusinessInput{business, business}, config)
+	if err != nil {
+		t.Fatalf("combined failed: %v", err)
+	}
+	assertDecimalEqual(t, combined.DeferredAmount, "8000", "combined deferred amount mismatch")
+}
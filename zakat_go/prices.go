@@ -0,0 +1,121 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MetalPriceBasis selects the quote used to value precious metals.
+type MetalPriceBasis string
+
+const (
+	// PriceBasisBid values metal at the dealer's buy price: what the owner
+	// would realize by selling. Preferred by many scholars and the default.
+	PriceBasisBid MetalPriceBasis = "bid"
+	// PriceBasisAsk values metal at the dealer's sell price.
+	PriceBasisAsk MetalPriceBasis = "ask"
+	// PriceBasisMid values metal at the midpoint of bid and ask.
+	PriceBasisMid MetalPriceBasis = "mid"
+)
+
+// MetalValuation selects which of a jeweler's two prices values a metal
+// holding.
+type MetalValuation string
+
+const (
+	// ValuationMarket values holdings at the market price per gram. The
+	// default.
+	ValuationMarket MetalValuation = "market"
+	// ValuationBuyBack values holdings at the jeweler's lower buy-back price:
+	// what the owner would actually realize, preferred by many scholars.
+	ValuationBuyBack MetalValuation = "buy_back"
+)
+
+// validate rejects unknown valuation prices.
+func (m MetalValuation) validate() error {
+	switch m {
+	case "", ValuationMarket, ValuationBuyBack:
+		return nil
+	}
+	return fieldError(ErrInvalidOption, "metal_valuation_price", string(m))
+}
+
+// valuationPrice resolves the per-gram price that values a metal holding
+// from its market price and an optional buy-back price. With only one of
+// the two, that one is used; with both, Config.MetalValuationPrice chooses.
+// The note records the choice for the result's assumptions and is empty
+// when no buy-back price is involved.
+func (c Config) valuationPrice(metal string, market decimal.Decimal, buyBack string) (price decimal.Decimal, note string, err error) {
+	if err = c.MetalValuationPrice.validate(); err != nil {
+		return
+	}
+	back, err := parseAmount(metal+"_buy_back_price_per_gram", buyBack)
+	if err != nil {
+		return
+	}
+	switch {
+	case back.IsZero():
+		if c.MetalValuationPrice == ValuationBuyBack {
+			note = fmt.Sprintf("No %s buy-back price given; valued at the market price of %s per gram.", metal, market)
+		}
+		return market, note, nil
+	case market.IsZero():
+		return back, fmt.Sprintf("Valued at the %s buy-back price of %s per gram, the only price given.", metal, back), nil
+	case c.MetalValuationPrice == ValuationBuyBack:
+		return back, fmt.Sprintf("Valued at the realizable %s buy-back price of %s per gram rather than the market price of %s (MetalValuationPrice).", metal, back, market), nil
+	default:
+		return market, fmt.Sprintf("Valued at the %s market price of %s per gram rather than the buy-back price of %s (MetalValuationPrice).", metal, market, back), nil
+	}
+}
+
+// prices returns the effective gold and silver prices per gram, used for
+// both metal valuation and the nisab.
+func (c Config) prices() (gold, silver decimal.Decimal, err error) {
+	if gold, err = c.metalPrice("gold", c.GoldPricePerGram, c.GoldBidPricePerGram, c.GoldAskPricePerGram); err != nil {
+		return
+	}
+	silver, err = c.metalPrice("silver", c.SilverPricePerGram, c.SilverBidPricePerGram, c.SilverAskPricePerGram)
+	return
+}
+
+// metalPrice resolves one metal's price. Without bid/ask quotes the single
+// price is used; with only one quote, that quote is used; with both, the
+// configured MetalPriceBasis chooses.
+func (c Config) metalPrice(metal, single, bid, ask string) (decimal.Decimal, error) {
+	price, err := parseAmount(metal+"_price_per_gram", single)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	bidPrice, err := parseAmount(metal+"_bid_price_per_gram", bid)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	askPrice, err := parseAmount(metal+"_ask_price_per_gram", ask)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	switch c.MetalPriceBasis {
+	case "", PriceBasisBid, PriceBasisAsk, PriceBasisMid:
+	default:
+		return decimal.Zero, fieldError(ErrInvalidOption, "metal_price_basis", string(c.MetalPriceBasis))
+	}
+
+	switch {
+	case bidPrice.IsZero() && askPrice.IsZero():
+		return price, nil
+	case askPrice.IsZero():
+		return bidPrice, nil
+	case bidPrice.IsZero():
+		return askPrice, nil
+	}
+	switch c.MetalPriceBasis {
+	case PriceBasisAsk:
+		return askPrice, nil
+	case PriceBasisMid:
+		return bidPrice.Add(askPrice).Div(decimal.NewFromInt(2)), nil
+	default:
+		return bidPrice, nil
+	}
+}
@@ -0,0 +1,99 @@
+package zakat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMetalPriceBasisBidVsAsk(t *testing.T) {
+	holding := GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}
+	config := NewConfig("", "1")
+	config.GoldBidPricePerGram = "95"
+	config.GoldAskPricePerGram = "105"
+
+	tests := []struct {
+		basis MetalPriceBasis
+		value string
+	}{
+		{"", "9500"},
+		{PriceBasisBid, "9500"},
+		{PriceBasisAsk, "10500"},
+		{PriceBasisMid, "10000"},
+	}
+	for _, tt := range tests {
+		config.MetalPriceBasis = tt.basis
+		result, err := CalculateGold(holding, config)
+		if err != nil {
+			t.Fatalf("basis %q: calculation failed: %v", tt.basis, err)
+		}
+		assertDecimalEqual(t, result.TotalAssets, tt.value, "basis "+string(tt.basis)+" valuation mismatch")
+	}
+}
+
+func TestMetalPriceSingleQuoteFallback(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.MetalPriceBasis = PriceBasisAsk
+	result, err := CalculateGold(GoldInput{WeightGrams: "100", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "single price should be used without quotes")
+
+	config.GoldBidPricePerGram = "98"
+	result, err = CalculateGold(GoldInput{WeightGrams: "100", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "9800", "the only quote given should be used")
+}
+
+func TestMetalPriceBasisInvalid(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.MetalPriceBasis = "spot"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestMetalValuationMarketVsBuyBack(t *testing.T) {
+	jewelry := GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}
+	config := NewConfig("100", "1")
+	config.GoldBuyBackPricePerGram = "90"
+
+	market, err := CalculateGold(jewelry, config)
+	if err != nil {
+		t.Fatalf("market valuation failed: %v", err)
+	}
+	assertDecimalEqual(t, market.TotalAssets, "10000", "default should value at the market price")
+	assertDecimalEqual(t, market.ZakatDue, "250", "market zakat_due mismatch")
+
+	config.MetalValuationPrice = ValuationBuyBack
+	buyBack, err := CalculateGold(jewelry, config)
+	if err != nil {
+		t.Fatalf("buy-back valuation failed: %v", err)
+	}
+	assertDecimalEqual(t, buyBack.TotalAssets, "9000", "buy-back valuation mismatch")
+	assertDecimalEqual(t, buyBack.ZakatDue, "225", "buy-back zakat_due mismatch")
+
+	for _, result := range []ZakatResult{market, buyBack} {
+		if len(result.Assumptions) == 0 || !strings.Contains(result.Assumptions[0], "MetalValuationPrice") {
+			t.Errorf("expected the valuation choice in assumptions, got %v", result.Assumptions)
+		}
+	}
+}
+
+func TestMetalValuationSinglePrice(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.MetalValuationPrice = ValuationBuyBack
+	result, err := CalculateSilver(SilverInput{WeightGrams: "1000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "1000", "without a buy-back price the market price should be used")
+
+	config.MetalValuationPrice = "spot"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
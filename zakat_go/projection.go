@@ -0,0 +1,53 @@
+package zakat
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProjectNisabDate estimates when a saver starting from currentBalance and
+// adding monthlyContribution each month will first reach the monetary
+// nisab. It is a planning estimate only: the nisab is held at today's
+// prices, and returns, withdrawals and price movements are ignored.
+//
+// Projections start at Config.PeriodEnd, or the current time when it is
+// unset. A balance already at the nisab returns that start. Otherwise the
+// date is the contribution month in which the nisab is reached, counted in
+// whole months from the start (clamped to the end of shorter months); the
+// hawl starts then. A balance below the nisab
+// with no contribution never reaches it and returns ErrInconsistentInput.
+func ProjectNisabDate(currentBalance, monthlyContribution string, config Config) (time.Time, error) {
+	balance, err := parseAmount("current_balance", currentBalance)
+	if err != nil {
+		return time.Time{}, err
+	}
+	contribution, err := parseAmount("monthly_contribution", monthlyContribution)
+	if err != nil {
+		return time.Time{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return time.Time{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	start := config.asOf()
+	if meetsNisab(balance, nisab) {
+		return start, nil
+	}
+	if !contribution.IsPositive() {
+		return time.Time{}, fieldError(ErrInconsistentInput, "monthly_contribution", monthlyContribution)
+	}
+	months := nisab.Sub(balance).Div(contribution).Ceil()
+	if months.GreaterThan(decimal.NewFromInt(maxProjectionMonths)) {
+		return time.Time{}, fieldError(ErrOverflow, "monthly_contribution", monthlyContribution)
+	}
+	return addMonthsClamped(start, int(months.IntPart())), nil
+}
+
+// maxProjectionMonths bounds projections to 1,000 years.
+const maxProjectionMonths = 12000
@@ -0,0 +1,70 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProjectNisabDate(t *testing.T) {
+	today := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	saved := now
+	t.Cleanup(func() { now = saved })
+	now = func() time.Time { return today }
+
+	// Silver nisab of 595: 100 + 5 x 100 = 600 reaches it in the fifth month.
+	config := NewConfig("100", "1")
+	date, err := ProjectNisabDate("100", "100", config)
+	if err != nil {
+		t.Fatalf("projection failed: %v", err)
+	}
+	if want := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("expected %s, got %s", want, date)
+	}
+
+	if _, err := ProjectNisabDate("100", "0", config); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput without contributions, got %v", err)
+	}
+}
+
+func TestProjectNisabDateAlreadyThere(t *testing.T) {
+	today := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	saved := now
+	t.Cleanup(func() { now = saved })
+	now = func() time.Time { return today }
+
+	date, err := ProjectNisabDate("1000", "", NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("projection failed: %v", err)
+	}
+	if !date.Equal(today) {
+		t.Errorf("a balance at the nisab should return now, got %s", date)
+	}
+}
+
+func TestProjectNisabDateFromPeriodEnd(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PeriodEnd = time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	date, err := ProjectNisabDate("100", "100", config)
+	if err != nil {
+		t.Fatalf("projection failed: %v", err)
+	}
+	if want := time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("expected %s, got %s", want, date)
+	}
+}
+
+func TestProjectNisabDateFromMonthEnd(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PeriodEnd = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// 500 + 100 = 600 reaches the 595 nisab after one month.
+	date, err := ProjectNisabDate("500", "100", config)
+	if err != nil {
+		t.Fatalf("projection failed: %v", err)
+	}
+	if want := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("expected %s, got %s", want, date)
+	}
+}
@@ -0,0 +1,84 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// PropertyForSaleInput holds input values for real estate held for resale.
+//
+// Property bought with the intention to sell (a "flip") is trade goods
+// (urud al-tijarah) and zakatable at 2.5% of its market value each hawl.
+// Property held to rent out is not: only the rental income it produces is
+// zakatable, so it does not belong here.
+type PropertyForSaleInput struct {
+	// MarketValue - current market value of the property
+	MarketValue string
+	// SellingCosts - expected costs of selling (agent fees, transfer taxes)
+	SellingCosts string
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all property amounts are valid non-negative decimals.
+func (in PropertyForSaleInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// propertyValues holds the parsed fields of a PropertyForSaleInput.
+type propertyValues struct {
+	market, costs, liabilities, disputed decimal.Decimal
+}
+
+func (in PropertyForSaleInput) parse() (v propertyValues, err error) {
+	if v.market, err = parseAmount("market_value", in.MarketValue); err != nil {
+		return
+	}
+	if v.costs, err = parseAmount("selling_costs", in.SellingCosts); err != nil {
+		return
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// CalculatePropertyForSale calculates zakat on property held for resale:
+// 2.5% of (market value - selling costs - liabilities) when at or above the
+// monetary nisab.
+func CalculatePropertyForSale(input PropertyForSaleInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	breakdown := []BreakdownLine{amountLine("step-market-value", "Market Value", v.market, OpAdd)}
+	if v.costs.IsPositive() {
+		breakdown = append(breakdown, amountLine("step-selling-costs", "Selling Costs", v.costs, OpSubtract))
+	}
+	net := decimal.Max(v.market.Sub(v.costs), decimal.Zero)
+	breakdown = append(breakdown, amountLine("step-net-market-value", "Net Market Value", net, OpResult))
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   net,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypePropertyForSale,
+		breakdown:     breakdown,
+		config:        config,
+	})
+}
@@ -0,0 +1,46 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculatePropertyForSaleFlip(t *testing.T) {
+	result, err := CalculatePropertyForSale(PropertyForSaleInput{
+		MarketValue:   "250000",
+		SellingCosts:  "10000",
+		Liabilities:   "40000",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("flip property above nisab should be payable")
+	}
+	assertDecimalEqual(t, result.NetAssets, "200000", "net_assets mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "5000", "zakat_due mismatch")
+	if result.AssetType != AssetTypePropertyForSale {
+		t.Errorf("asset type mismatch: %s", result.AssetType)
+	}
+}
+
+func TestCalculatePropertyForSaleCostsExceedValue(t *testing.T) {
+	result, err := CalculatePropertyForSale(PropertyForSaleInput{
+		MarketValue:   "5000",
+		SellingCosts:  "6000",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("property worth less than its selling costs should not be payable")
+	}
+}
+
+func TestPropertyForSaleValidate(t *testing.T) {
+	if err := (PropertyForSaleInput{MarketValue: "x"}).Validate(); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("expected ErrInvalidDecimal, got %v", err)
+	}
+}
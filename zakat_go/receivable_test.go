@@ -0,0 +1,41 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFutureReceivablePolicy(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PeriodEnd = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := BusinessInput{
+		CashOnHand: "10000",
+		ReceivableItems: []Receivable{
+			{Debtor: "Toko A", Amount: "2000", DueDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{Debtor: "Toko B", Amount: "4000", DueDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		HawlSatisfied: true,
+	}
+
+	included, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, included.TotalAssets, "16000", "IncludeNow should count every receivable")
+	assertDecimalEqual(t, included.ZakatDue, "400", "IncludeNow zakat_due mismatch")
+
+	config.FutureReceivablePolicy = FutureReceivableDeferUntilDue
+	deferred, err := CalculateBusiness(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, deferred.TotalAssets, "12000", "the receivable due next year should be left out")
+	assertDecimalEqual(t, deferred.ZakatDue, "300", "DeferUntilDue zakat_due mismatch")
+	assertDecimalEqual(t, deferred.DeferredAmount, "4000", "deferred receivable mismatch")
+
+	config.FutureReceivablePolicy = "later"
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
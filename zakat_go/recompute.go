@@ -0,0 +1,83 @@
+package zakat
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// calculateInput dispatches an input value to its calculator, verifies the
+// result under Config.VerifyResults and stamps its InputsHash.
+func calculateInput(input any, config Config) (ZakatResult, error) {
+	result, err := dispatchInput(input, config)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	if config.VerifyResults {
+		if result, err = verifyResult(input, config, result); err != nil {
+			return ZakatResult{}, err
+		}
+	}
+	result.InputsHash = HashInputs(input, config)
+	return result, nil
+}
+
+// dispatchInput dispatches an input value to its calculator. Portfolio
+// inputs return the pooled result.
+func dispatchInput(input any, config Config) (ZakatResult, error) {
+	switch in := input.(type) {
+	case BusinessInput:
+		return CalculateBusiness(in, config)
+	case GoldInput:
+		return CalculateGold(in, config)
+	case GoldValuedItemsInput:
+		return CalculateGoldValuedItems(in, config)
+	case SilverInput:
+		return CalculateSilver(in, config)
+	case CashInput:
+		return CalculateCash(in, config)
+	case PropertyForSaleInput:
+		return CalculatePropertyForSale(in, config)
+	case EndOfServiceInput:
+		return CalculateEndOfService(in, config)
+	case CryptoInput:
+		return CalculateCrypto(in, config)
+	case CommodityInput:
+		return CalculateCommodity(in, config)
+	case AgricultureInput:
+		return CalculateAgriculture(in, config)
+	case StockPortfolioInput:
+		return CalculateStockPortfolio(in, config)
+	case CooperativeInput:
+		return CalculateCooperative(in, config)
+	case InsuranceInput:
+		return CalculateInsurance(in, config)
+	case FitrInput:
+		return CalculateFitr(in, config)
+	case GemstoneInput:
+		return CalculateGemstone(in, config)
+	case CrowdfundingInput:
+		return CalculateCrowdfunding(in, config)
+	case StockOptionInput:
+		return CalculateStockOption(in, config)
+	case PortfolioInput:
+		result, err := CalculatePortfolio(in, config)
+		return result.ZakatResult, err
+	default:
+		return ZakatResult{}, fmt.Errorf("%w: %T", ErrUnsupportedInput, input)
+	}
+}
+
+// Recompute recalculates a stored result from its ConfigSnapshot and the
+// original inputs, for audit re-runs and regression checks against
+// historical records. The returned result is fresh; comparing its ZakatDue
+// with the stored one verifies the record.
+func Recompute(result ZakatResult, inputs any) (ZakatResult, error) {
+	snapshot := result.ConfigSnapshot
+	// Every calculated result carries its config; only a result built by
+	// hand has a zero snapshot. Prices may come from bid/ask or buy-back
+	// fields, so the spot prices alone do not tell.
+	if reflect.ValueOf(snapshot).IsZero() {
+		return ZakatResult{}, ErrNoSnapshot
+	}
+	return calculateInput(inputs, snapshot)
+}
@@ -0,0 +1,54 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecomputeRoundTrip(t *testing.T) {
+	input := BusinessInput{CashOnHand: "12000.50", InventoryValue: "3000", Liabilities: "500", HawlSatisfied: true}
+	stored, err := CalculateBusiness(input, NewConfig("99.99", "0.85").WithMadhabType(MadhabShafi))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+
+	recomputed, err := Recompute(stored, input)
+	if err != nil {
+		t.Fatalf("recompute failed: %v", err)
+	}
+	if !DecimalEqual(recomputed.ZakatDue, stored.ZakatDue, "0.0000001") {
+		t.Errorf("recomputed due %s differs from stored %s", recomputed.ZakatDue, stored.ZakatDue)
+	}
+	if recomputed.ConfigSnapshot.Madhab != MadhabShafi {
+		t.Errorf("snapshot madhab not preserved: %s", recomputed.ConfigSnapshot.Madhab)
+	}
+}
+
+func TestRecomputeErrors(t *testing.T) {
+	if _, err := Recompute(ZakatResult{}, CashInput{}); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("expected ErrNoSnapshot, got %v", err)
+	}
+	stored := ZakatResult{ConfigSnapshot: NewConfig("100", "1")}
+	if _, err := Recompute(stored, "not an input"); !errors.Is(err, ErrUnsupportedInput) {
+		t.Errorf("expected ErrUnsupportedInput, got %v", err)
+	}
+}
+
+func TestRecomputeBidAskOnlySnapshot(t *testing.T) {
+	config := NewConfig("", "")
+	config.GoldBidPricePerGram = "95"
+	config.GoldAskPricePerGram = "105"
+	config.SilverBidPricePerGram = "0.9"
+	config.SilverAskPricePerGram = "1.1"
+	input := GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}
+
+	stored, err := CalculateGold(input, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	recomputed, err := Recompute(stored, input)
+	if err != nil {
+		t.Fatalf("a bid/ask-only snapshot should recompute: %v", err)
+	}
+	assertDecimalEqual(t, recomputed.ZakatDue, stored.ZakatDue, "recomputed due mismatch")
+}
@@ -0,0 +1,236 @@
+package zakat
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// RenderedField is one display-ready label and value.
+type RenderedField struct {
+	Label string
+	Value string
+}
+
+// RenderedResult is a ZakatResult formatted for display in one language and
+// currency. Amounts carry locale grouping and the currency symbol; breakdown
+// lines keep their own labels, and weights and counts render as plain
+// numbers.
+type RenderedResult struct {
+	AssetType      RenderedField
+	Status         RenderedField
+	ZakatDue       RenderedField
+	TotalAssets    RenderedField
+	NetAssets      RenderedField
+	NisabThreshold RenderedField
+	Breakdown      []RenderedField
+}
+
+// numberLocale holds a language's number format and field labels.
+type numberLocale struct {
+	group, decimal string
+	labels         map[string]string
+}
+
+var numberLocales = map[string]numberLocale{
+	"en": {group: ",", decimal: ".", labels: map[string]string{
+		"asset_type":      "Asset Type",
+		"status":          "Status",
+		"zakat_due":       "Zakat Due",
+		"total_assets":    "Total Assets",
+		"net_assets":      "Net Assets",
+		"nisab_threshold": "Nisab Threshold",
+		"payable":         "Payable",
+		"exempt":          "Not Payable",
+		"madhab":          "Madhab",
+		"yes":             "yes",
+		"no":              "no",
+
+		"asnaf.fuqara":            "The Poor (al-Fuqara)",
+		"asnaf.fuqara.desc":       "Those with little or no means to meet their basic needs.",
+		"asnaf.masakin":           "The Needy (al-Masakin)",
+		"asnaf.masakin.desc":      "Those whose earnings do not cover their basic needs.",
+		"asnaf.amil":              "Zakat Administrators (al-'Amilin)",
+		"asnaf.amil.desc":         "Those appointed to collect and distribute zakat.",
+		"asnaf.muallaf":           "Those Whose Hearts Are Reconciled (al-Mu'allafah)",
+		"asnaf.muallaf.desc":      "New Muslims and others whose hearts are to be won or strengthened.",
+		"asnaf.riqab":             "Freeing Captives (fi al-Riqab)",
+		"asnaf.riqab.desc":        "Freeing those in bondage or captivity.",
+		"asnaf.gharimin":          "Debtors (al-Gharimin)",
+		"asnaf.gharimin.desc":     "Those burdened by debts taken for lawful needs they cannot repay.",
+		"asnaf.fisabilillah":      "In the Cause of Allah (fi Sabilillah)",
+		"asnaf.fisabilillah.desc": "Efforts in the way of Allah, such as defending the community.",
+		"asnaf.ibnusabil":         "The Stranded Traveller (Ibn al-Sabil)",
+		"asnaf.ibnusabil.desc":    "Travellers cut off from their means, though they may be wealthy at home.",
+	}},
+	"id": {group: ".", decimal: ",", labels: map[string]string{
+		"asset_type":      "Jenis Harta",
+		"status":          "Status",
+		"zakat_due":       "Zakat Wajib Dibayar",
+		"total_assets":    "Total Harta",
+		"net_assets":      "Harta Bersih",
+		"nisab_threshold": "Batas Nisab",
+		"payable":         "Wajib Zakat",
+		"exempt":          "Tidak Wajib Zakat",
+		"madhab":          "Mazhab",
+		"yes":             "ya",
+		"no":              "tidak",
+
+		"asnaf.fuqara":            "Fakir",
+		"asnaf.fuqara.desc":       "Orang yang hampir tidak memiliki harta untuk memenuhi kebutuhan pokok.",
+		"asnaf.masakin":           "Miskin",
+		"asnaf.masakin.desc":      "Orang yang penghasilannya tidak mencukupi kebutuhan pokok.",
+		"asnaf.amil":              "Amil",
+		"asnaf.amil.desc":         "Petugas yang ditunjuk untuk mengumpulkan dan menyalurkan zakat.",
+		"asnaf.muallaf":           "Mualaf",
+		"asnaf.muallaf.desc":      "Orang yang baru masuk Islam atau yang hatinya perlu dikuatkan.",
+		"asnaf.riqab":             "Riqab",
+		"asnaf.riqab.desc":        "Memerdekakan orang yang terbelenggu perbudakan atau tawanan.",
+		"asnaf.gharimin":          "Gharim",
+		"asnaf.gharimin.desc":     "Orang yang terlilit utang untuk kebutuhan halal dan tidak mampu melunasinya.",
+		"asnaf.fisabilillah":      "Fisabilillah",
+		"asnaf.fisabilillah.desc": "Usaha di jalan Allah, seperti membela dan menegakkan agama.",
+		"asnaf.ibnusabil":         "Ibnu Sabil",
+		"asnaf.ibnusabil.desc":    "Musafir yang kehabisan bekal dalam perjalanan, walaupun kaya di negerinya.",
+	}},
+}
+
+// localeOrEnglish returns the locale for lang, falling back to English for
+// an unknown lang.
+func localeOrEnglish(lang string) numberLocale {
+	if locale, ok := numberLocales[strings.ToLower(strings.TrimSpace(lang))]; ok {
+		return locale
+	}
+	return numberLocales["en"]
+}
+
+// currencySymbols are the display symbols of supported currencies. Symbols
+// are placed before the amount; currencies without an entry use their code.
+var currencySymbols = map[string]string{
+	"EUR": "€", "GBP": "£", "IDR": "Rp", "INR": "₹", "JPY": "¥",
+	"MYR": "RM", "PKR": "Rs", "USD": "$",
+}
+
+// Render formats the result for display in lang ("en" or "id") and currency
+// (an ISO 4217 code), combining number grouping, currency symbol and
+// localized field labels. Amounts are rounded half-up to the currency's
+// minor unit for display only.
+func (r ZakatResult) Render(lang, currency string) (RenderedResult, error) {
+	locale, ok := numberLocales[strings.ToLower(strings.TrimSpace(lang))]
+	if !ok {
+		return RenderedResult{}, fieldError(ErrInvalidOption, "lang", lang)
+	}
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	places, ok := currencyDecimals[code]
+	if !ok {
+		return RenderedResult{}, fieldError(ErrInvalidOption, "currency", currency)
+	}
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		symbol = code + " "
+	}
+	money := func(s string) string {
+		return symbol + locale.formatNumber(ToDecimal(s), places)
+	}
+
+	status := locale.labels["exempt"]
+	if r.IsPayable {
+		status = locale.labels["payable"]
+	}
+	rendered := RenderedResult{
+		AssetType:      RenderedField{locale.labels["asset_type"], r.AssetType},
+		Status:         RenderedField{locale.labels["status"], status},
+		ZakatDue:       RenderedField{locale.labels["zakat_due"], money(r.ZakatDue)},
+		TotalAssets:    RenderedField{locale.labels["total_assets"], money(r.TotalAssets)},
+		NetAssets:      RenderedField{locale.labels["net_assets"], money(r.NetAssets)},
+		NisabThreshold: RenderedField{locale.labels["nisab_threshold"], money(r.NisabThreshold)},
+	}
+	for _, line := range r.Breakdown {
+		field := RenderedField{Label: line.Label}
+		switch {
+		case line.Amount == "":
+		case line.Op == OpRate:
+			field.Value = locale.formatNumber(ToDecimal(line.Amount).Mul(hundred), -1) + "%"
+		case line.Unit == UnitCount:
+			field.Value = locale.formatNumber(ToDecimal(line.Amount), -1)
+		case line.Unit != UnitCurrency:
+			field.Value = locale.formatNumber(ToDecimal(line.Amount), -1) + " " + string(line.Unit)
+		default:
+			field.Value = money(line.Amount)
+		}
+		rendered.Breakdown = append(rendered.Breakdown, field)
+	}
+	return rendered, nil
+}
+
+// formatNumber formats d with the locale's grouping and decimal separators,
+// rounded half-up to places fractional digits. Negative places keeps every
+// digit.
+func (l numberLocale) formatNumber(d decimal.Decimal, places int32) string {
+	s := d.String()
+	if places >= 0 {
+		s = d.StringFixed(places)
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.group)
+		}
+		b.WriteRune(digit)
+	}
+	if frac != "" {
+		b.WriteString(l.decimal)
+		b.WriteString(frac)
+	}
+	return sign + b.String()
+}
+
+// madhabNames are the display names of the supported madhabs.
+var madhabNames = map[Madhab]string{
+	MadhabHanafi:  "Hanafi",
+	MadhabShafi:   "Shafi'i",
+	MadhabMaliki:  "Maliki",
+	MadhabHanbali: "Hanbali",
+}
+
+// RenderMadhabComparison renders the results of CompareMadhabs as an aligned
+// text table with one row per madhab, in the order Hanafi, Shafi'i, Maliki,
+// Hanbali. Madhabs missing from results are shown with "-". Labels use lang
+// ("en" or "id"); an unknown lang falls back to English.
+func RenderMadhabComparison(results map[Madhab]ZakatResult, lang string) string {
+	locale := localeOrEnglish(lang)
+
+	rows := [][]string{{locale.labels["madhab"], locale.labels["payable"], locale.labels["zakat_due"]}}
+	for _, m := range madhabs {
+		result, ok := results[m]
+		switch {
+		case !ok:
+			rows = append(rows, []string{madhabNames[m], "-", "-"})
+		case result.IsPayable:
+			rows = append(rows, []string{madhabNames[m], locale.labels["yes"], locale.formatNumber(ToDecimal(result.ZakatDue), 2)})
+		default:
+			rows = append(rows, []string{madhabNames[m], locale.labels["no"], locale.formatNumber(ToDecimal(result.ZakatDue), 2)})
+		}
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		// Text columns are left-aligned; the amount column is right-aligned.
+		fmt.Fprintf(&b, "%-*s  %-*s  %*s\n", widths[0], row[0], widths[1], row[1], widths[2], row[2])
+	}
+	return b.String()
+}
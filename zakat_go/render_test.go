@@ -0,0 +1,172 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenderEnglishUSD(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "1234567.5", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	rendered, err := result.Render("en", "USD")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	checks := []struct{ got, want RenderedField }{
+		{rendered.AssetType, RenderedField{"Asset Type", "cash"}},
+		{rendered.Status, RenderedField{"Status", "Payable"}},
+		{rendered.ZakatDue, RenderedField{"Zakat Due", "$30,864.19"}},
+		{rendered.TotalAssets, RenderedField{"Total Assets", "$1,234,567.50"}},
+		{rendered.NetAssets, RenderedField{"Net Assets", "$1,234,567.50"}},
+		{rendered.NisabThreshold, RenderedField{"Nisab Threshold", "$595.00"}},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("rendered %+v, want %+v", c.got, c.want)
+		}
+	}
+	if len(rendered.Breakdown) != len(result.Breakdown) {
+		t.Fatalf("expected %d breakdown lines, got %d", len(result.Breakdown), len(rendered.Breakdown))
+	}
+	for i, line := range result.Breakdown {
+		if line.Op == OpRate && rendered.Breakdown[i].Value != "2.5%" {
+			t.Errorf("rate rendered as %q, want 2.5%%", rendered.Breakdown[i].Value)
+		}
+	}
+}
+
+func TestRenderIndonesianIDR(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "100000000", HawlSatisfied: true}, NewConfig("1500000", "15000"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	rendered, err := result.Render("id", "IDR")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	checks := []struct{ got, want RenderedField }{
+		{rendered.Status, RenderedField{"Status", "Wajib Zakat"}},
+		{rendered.ZakatDue, RenderedField{"Zakat Wajib Dibayar", "Rp2.500.000,00"}},
+		{rendered.TotalAssets, RenderedField{"Total Harta", "Rp100.000.000,00"}},
+		{rendered.NisabThreshold, RenderedField{"Batas Nisab", "Rp8.925.000,00"}},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("rendered %+v, want %+v", c.got, c.want)
+		}
+	}
+	for i, line := range result.Breakdown {
+		if line.Op == OpRate && rendered.Breakdown[i].Value != "2,5%" {
+			t.Errorf("rate rendered as %q, want 2,5%%", rendered.Breakdown[i].Value)
+		}
+	}
+}
+
+func TestRenderUnknownLocale(t *testing.T) {
+	if _, err := (ZakatResult{}).Render("xx", "USD"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown language, got %v", err)
+	}
+	if _, err := (ZakatResult{}).Render("en", "XYZ"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown currency, got %v", err)
+	}
+}
+
+func TestRenderMadhabComparisonGolden(t *testing.T) {
+	input := GoldInput{WeightGrams: "100", Purity: "24", Usage: "PersonalUse", HawlSatisfied: true}
+	results, err := CompareMadhabs(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("CompareMadhabs failed: %v", err)
+	}
+	delete(results, MadhabMaliki)
+
+	want := "" +
+		"Madhab   Payable  Zakat Due\n" +
+		"Hanafi   yes         250.00\n" +
+		"Shafi'i  no            0.00\n" +
+		"Maliki   -                -\n" +
+		"Hanbali  no            0.00\n"
+	if got := RenderMadhabComparison(results, "en"); got != want {
+		t.Errorf("table mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	wantID := "" +
+		"Mazhab   Wajib Zakat  Zakat Wajib Dibayar\n" +
+		"Hanafi   ya                        250,00\n" +
+		"Shafi'i  tidak                       0,00\n" +
+		"Maliki   -                              -\n" +
+		"Hanbali  tidak                       0,00\n"
+	if got := RenderMadhabComparison(results, "id"); got != wantID {
+		t.Errorf("table mismatch\ngot:\n%s\nwant:\n%s", got, wantID)
+	}
+}
+
+// renderedByKey maps each breakdown key of result to its rendered value.
+func renderedByKey(t *testing.T, result ZakatResult, lang, currency string) map[string]string {
+	t.Helper()
+	rendered, err := result.Render(lang, currency)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	values := make(map[string]string, len(result.Breakdown))
+	for i, line := range result.Breakdown {
+		values[line.Key] = rendered.Breakdown[i].Value
+	}
+	return values
+}
+
+func TestRenderGoldWeights(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.MetalNisabInGrams = true
+	result, err := CalculateGold(GoldInput{WeightGrams: "1000", Purity: "18", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	values := renderedByKey(t, result, "en", "USD")
+	for key, want := range map[string]string{
+		"step-weight":           "1,000 g",
+		"step-effective-weight": "750 g",
+		"step-nisab-grams":      "85 g",
+		"step-price-per-gram":   "$100.00",
+		"step-total-value":      "$75,000.00",
+	} {
+		if values[key] != want {
+			t.Errorf("%s rendered as %q, want %q", key, values[key], want)
+		}
+	}
+}
+
+func TestRenderAgricultureWeight(t *testing.T) {
+	result, err := CalculateAgriculture(AgricultureInput{HarvestWeightKg: "1500.5", PricePerKg: "2"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	values := renderedByKey(t, result, "id", "IDR")
+	if got := values["step-harvest-weight"]; got != "1.500,5 kg" {
+		t.Errorf("harvest weight rendered as %q, want %q", got, "1.500,5 kg")
+	}
+	if got := values["step-price-per-kg"]; got != "Rp2,00" {
+		t.Errorf("price per kg rendered as %q, want %q", got, "Rp2,00")
+	}
+}
+
+func TestRenderFitrCounts(t *testing.T) {
+	result, err := CalculateFitr(FitrInput{PersonCount: 4, KgPerPerson: "2.5", PricePerKg: "3"}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	values := renderedByKey(t, result, "en", "USD")
+	for key, want := range map[string]string{
+		"step-person-count":      "4",
+		"step-amount-per-person": "2.5 kg",
+		"step-price-per-kg":      "$3.00",
+		"status-due":             "$30.00",
+	} {
+		if values[key] != want {
+			t.Errorf("%s rendered as %q, want %q", key, values[key], want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// EndOfServiceInput holds an accrued end-of-service gratuity, common in Gulf
+// employment contracts.
+type EndOfServiceInput struct {
+	// AccruedBenefit - gratuity accrued to date
+	AccruedBenefit string
+	// Accessible - whether the employee can withdraw the benefit now
+	Accessible bool
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that the accrued benefit is a valid non-negative decimal.
+func (in EndOfServiceInput) Validate() error {
+	_, err := parseAmount("accrued_benefit", in.AccruedBenefit)
+	return err
+}
+
+// CalculateEndOfService calculates zakat on an end-of-service gratuity.
+//
+// An accessible benefit is owned wealth and zakatable like cash. An
+// inaccessible one is not yet fully owned (milk naqis), like a locked
+// retirement fund: nothing is due now and the benefit is reported in
+// DeferredAmount, to be zakated once received.
+func CalculateEndOfService(input EndOfServiceInput, config Config) (ZakatResult, error) {
+	benefit, err := parseAmount("accrued_benefit", input.AccruedBenefit)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	return calculateRestricted(restrictedParams{
+		amount:        benefit,
+		accessible:    input.Accessible,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeEndOfService,
+		key:           "step-accrued-benefit",
+		label:         "Accrued End-of-Service Benefit",
+		description:   "End-of-service benefit",
+	}, config)
+}
+
+// InsuranceInput holds the cash-surrender value of a participating (takaful)
+// savings policy.
+type InsuranceInput struct {
+	// CashSurrenderValue - amount paid out if the policy were surrendered now
+	CashSurrenderValue string
+	// Accessible - whether the policyholder can surrender or withdraw now
+	Accessible bool
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that the surrender value is a valid non-negative decimal.
+func (in InsuranceInput) Validate() error {
+	_, err := parseAmount("cash_surrender_value", in.CashSurrenderValue)
+	return err
+}
+
+// CalculateInsurance calculates zakat on a takaful policy's cash-surrender
+// value. Only the savings (investment) portion the holder could take out is
+// owned wealth: an accessible surrender value is zakatable like cash, an
+// inaccessible one is reported in DeferredAmount until it can be received.
+// Tabarru' (donation) contributions to the risk pool are not the holder's
+// and are never included.
+func CalculateInsurance(input InsuranceInput, config Config) (ZakatResult, error) {
+	value, err := parseAmount("cash_surrender_value", input.CashSurrenderValue)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	return calculateRestricted(restrictedParams{
+		amount:        value,
+		accessible:    input.Accessible,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeInsurance,
+		key:           "step-cash-surrender-value",
+		label:         "Cash-Surrender Value",
+		description:   "Cash-surrender value",
+	}, config)
+}
+
+// restrictedParams describe wealth that is zakatable only once accessible.
+type restrictedParams struct {
+	amount        decimal.Decimal
+	accessible    bool
+	hawlSatisfied bool
+	assetType     string
+	// key and label name the breakdown line of the amount; description
+	// starts the deferral note.
+	key, label, description string
+}
+
+// calculateRestricted zakats accessible wealth like cash against the
+// monetary nisab and defers inaccessible wealth.
+func calculateRestricted(p restrictedParams, config Config) (ZakatResult, error) {
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	if !p.accessible {
+		result := exemptResult(p.assetType, "Inaccessible amount deferred until received",
+			[]string{fmt.Sprintf("%s of %s is inaccessible; zakat is due on it once received.", p.description, p.amount)}, config)
+		result.NisabThreshold = nisab.String()
+		result.DeferredAmount = p.amount.String()
+		return result, nil
+	}
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   p.amount,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: p.hawlSatisfied,
+		assetType:     p.assetType,
+		breakdown:     []BreakdownLine{amountLine(p.key, p.label, p.amount, OpAdd)},
+		config:        config,
+	})
+}
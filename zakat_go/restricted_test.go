@@ -0,0 +1,51 @@
+package zakat
+
+import "testing"
+
+func TestCalculateEndOfServiceAccessible(t *testing.T) {
+	result, err := CalculateEndOfService(EndOfServiceInput{AccruedBenefit: "40000", Accessible: true, HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("accessible gratuity above nisab should be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "1000", "zakat_due mismatch")
+	if result.DeferredAmount != "" {
+		t.Errorf("accessible gratuity should not be deferred, got %q", result.DeferredAmount)
+	}
+}
+
+func TestCalculateEndOfServiceInaccessible(t *testing.T) {
+	result, err := CalculateEndOfService(EndOfServiceInput{AccruedBenefit: "40000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("inaccessible gratuity should not be payable now")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+	assertDecimalEqual(t, result.DeferredAmount, "40000", "deferred amount mismatch")
+}
+
+func TestCalculateInsuranceAccessible(t *testing.T) {
+	result, err := CalculateInsurance(InsuranceInput{CashSurrenderValue: "20000", Accessible: true, HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if !result.IsPayable {
+		t.Errorf("accessible surrender value above nisab should be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "500", "zakat_due mismatch")
+}
+
+func TestCalculateInsuranceInaccessible(t *testing.T) {
+	result, err := CalculateInsurance(InsuranceInput{CashSurrenderValue: "20000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Errorf("inaccessible surrender value should not be payable now")
+	}
+	assertDecimalEqual(t, result.DeferredAmount, "20000", "deferred amount mismatch")
+}
@@ -0,0 +1,128 @@
+package zakat
+
+import (
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rounding selects how an exact amount is rounded to a payable figure.
+type Rounding string
+
+const (
+	// RoundHalfUp rounds to the nearest value, halves away from zero.
+	RoundHalfUp Rounding = "half_up"
+	// RoundHalfEven rounds to the nearest value, halves to the even digit.
+	RoundHalfEven Rounding = "half_even"
+	// RoundUp rounds up, so the payment never falls short of the exact due.
+	RoundUp Rounding = "up"
+	// RoundDown rounds down (truncates).
+	RoundDown Rounding = "down"
+)
+
+// round rounds d to places fractional digits. Unknown modes round half-up.
+func (r Rounding) round(d decimal.Decimal, places int32) decimal.Decimal {
+	switch r {
+	case RoundHalfEven:
+		return d.RoundBank(places)
+	case RoundUp:
+		return d.RoundCeil(places)
+	case RoundDown:
+		return d.RoundFloor(places)
+	default:
+		return d.Round(places)
+	}
+}
+
+// PayableRounded returns ZakatDue rounded for an actual transfer.
+//
+// ZakatDue itself stays exact for records, so rounding is applied only at
+// payment and never compounds into stored results. An invalid ZakatDue is
+// treated as zero.
+func (r ZakatResult) PayableRounded(mode Rounding, places int) string {
+	return mode.round(ToDecimal(r.ZakatDue), int32(places)).StringFixed(int32(places))
+}
+
+// EffectiveRate returns ZakatDue as a fraction of NetAssets, which is below
+// the nominal rate when an exemption, credit or personal-use share reduced
+// the due. It is zero when there are no net assets.
+func (r ZakatResult) EffectiveRate() decimal.Decimal {
+	net := r.NetAssetsDecimal()
+	if !net.IsPositive() {
+		return decimal.Zero
+	}
+	return r.ZakatDueDecimal().Div(net)
+}
+
+// EffectiveRateFixed renders EffectiveRate as a percentage with exactly
+// places decimal places, rounded half-up, e.g. "2.50%".
+func (r ZakatResult) EffectiveRateFixed(places int) string {
+	return r.EffectiveRate().Mul(decimal.NewFromInt(100)).StringFixed(int32(places)) + "%"
+}
+
+// RoundingFor returns the payment rounding for results of assetType:
+// RoundingByAssetType's entry if present, otherwise PaymentRounding.
+func (c Config) RoundingFor(assetType string) Rounding {
+	if mode, ok := c.RoundingByAssetType[assetType]; ok {
+		return mode
+	}
+	return c.PaymentRounding
+}
+
+// validateRoundingByAssetType rejects overrides for unknown asset types,
+// which would otherwise be silently ignored.
+func (c Config) validateRoundingByAssetType() error {
+	for assetType := range c.RoundingByAssetType {
+		if !canonicalAssetTypes[assetType] {
+			return fieldError(ErrInvalidOption, "rounding_by_asset_type", assetType)
+		}
+	}
+	return nil
+}
+
+// currencyDecimals are the ISO 4217 minor-unit exponents of supported
+// currencies.
+var currencyDecimals = map[string]int32{
+	"AED": 2, "AUD": 2, "BDT": 2, "BHD": 3, "BND": 2, "CAD": 2, "CHF": 2,
+	"CNY": 2, "EGP": 2, "EUR": 2, "GBP": 2, "IDR": 2, "INR": 2, "IQD": 3,
+	"JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "LYD": 3, "MAD": 2, "MYR": 2,
+	"NGN": 2, "OMR": 3, "PKR": 2, "QAR": 2, "SAR": 2, "SGD": 2, "TND": 3,
+	"TRY": 2, "USD": 2,
+}
+
+// minorUnitPlaces returns the minor-unit exponent of currency, or 2 (cents)
+// when it is empty or not a known currency.
+func minorUnitPlaces(currency string) int32 {
+	if places, ok := currencyDecimals[strings.ToUpper(strings.TrimSpace(currency))]; ok {
+		return places
+	}
+	return 2
+}
+
+// DueMinorUnits returns ZakatDue as an integer count of the currency's
+// smallest unit (e.g. cents for USD), for payment APIs that take integer
+// amounts. The due is rounded with ConfigSnapshot.RoundingFor(AssetType).
+//
+// Returns ErrInvalidOption for an unknown currency and ErrOverflow when the
+// amount does not fit in an int64.
+func (r ZakatResult) DueMinorUnits(currency string) (int64, error) {
+	places, ok := currencyDecimals[strings.ToUpper(strings.TrimSpace(currency))]
+	if !ok {
+		return 0, fieldError(ErrInvalidOption, "currency", currency)
+	}
+	due, err := decimal.NewFromString(r.ZakatDue)
+	if err != nil {
+		return 0, fieldError(ErrInvalidDecimal, "zakat_due", r.ZakatDue)
+	}
+	units := r.ConfigSnapshot.RoundingFor(r.AssetType).round(due, places).Shift(places)
+	if units.GreaterThan(maxInt64) || units.LessThan(minInt64) {
+		return 0, fieldError(ErrOverflow, "zakat_due", r.ZakatDue)
+	}
+	return units.IntPart(), nil
+}
+
+var (
+	maxInt64 = decimal.NewFromInt(math.MaxInt64)
+	minInt64 = decimal.NewFromInt(math.MinInt64)
+)
@@ -0,0 +1,124 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPayableRoundedKeepsExactDue(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "10000.5", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.ZakatDue, "250.0125", "exact zakat_due mismatch")
+
+	tests := []struct {
+		mode   Rounding
+		places int
+		want   string
+	}{
+		{RoundHalfUp, 2, "250.01"},
+		{RoundUp, 2, "250.02"},
+		{RoundDown, 2, "250.01"},
+		{RoundHalfEven, 3, "250.012"},
+		{RoundHalfUp, 0, "250"},
+	}
+	for _, tt := range tests {
+		if got := result.PayableRounded(tt.mode, tt.places); got != tt.want {
+			t.Errorf("PayableRounded(%s, %d) = %s, want %s", tt.mode, tt.places, got, tt.want)
+		}
+	}
+	if result.ZakatDue == result.PayableRounded(RoundUp, 2) {
+		t.Errorf("exact due should differ from the rounded payable amount")
+	}
+}
+
+func TestDueMinorUnits(t *testing.T) {
+	result := ZakatResult{ZakatDue: "250.0125"}
+
+	cents, err := result.DueMinorUnits("USD")
+	if err != nil {
+		t.Fatalf("DueMinorUnits(USD) failed: %v", err)
+	}
+	if cents != 25001 {
+		t.Errorf("DueMinorUnits(USD) = %d, want 25001", cents)
+	}
+
+	yen, err := result.DueMinorUnits("jpy")
+	if err != nil {
+		t.Fatalf("DueMinorUnits(JPY) failed: %v", err)
+	}
+	if yen != 250 {
+		t.Errorf("DueMinorUnits(JPY) = %d, want 250", yen)
+	}
+
+	result.ConfigSnapshot.PaymentRounding = RoundUp
+	if cents, _ := result.DueMinorUnits("USD"); cents != 25002 {
+		t.Errorf("DueMinorUnits(USD) with RoundUp = %d, want 25002", cents)
+	}
+}
+
+func TestDueMinorUnitsErrors(t *testing.T) {
+	huge := ZakatResult{ZakatDue: "92233720368547758.08"}
+	if _, err := huge.DueMinorUnits("USD"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if _, err := (ZakatResult{ZakatDue: "1"}).DueMinorUnits("XYZ"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for unknown currency, got %v", err)
+	}
+}
+
+func TestEffectiveRateFixed(t *testing.T) {
+	config := NewConfig("100", "1")
+	full, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	// A 10000 exemption on 30000 leaves 500 due: 1.6666...% effective.
+	config.PersonalExemption = "10000"
+	exempted, err := CalculateCash(CashInput{CashOnHand: "30000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+
+	tests := []struct {
+		result ZakatResult
+		places int
+		want   string
+	}{
+		{full, 2, "2.50%"},
+		{full, 4, "2.5000%"},
+		{exempted, 2, "1.67%"},
+		{exempted, 4, "1.6667%"},
+		{ZakatResult{ZakatDue: "0", NetAssets: "0"}, 2, "0.00%"},
+	}
+	for _, tt := range tests {
+		if got := tt.result.EffectiveRateFixed(tt.places); got != tt.want {
+			t.Errorf("%s/%s to %d places: expected %s, got %s", tt.result.ZakatDue, tt.result.NetAssets, tt.places, tt.want, got)
+		}
+	}
+}
+
+func TestRoundingByAssetType(t *testing.T) {
+	config := NewConfig("100", "1")
+	config.PaymentRounding = RoundUp
+	config.RoundingByAssetType = map[string]Rounding{AssetTypeAgriculture: RoundDown}
+
+	cash := ZakatResult{AssetType: AssetTypeCash, ZakatDue: "250.0125", ConfigSnapshot: config}
+	crops := ZakatResult{AssetType: AssetTypeAgriculture, ZakatDue: "250.0125", ConfigSnapshot: config}
+
+	if cents, _ := cash.DueMinorUnits("USD"); cents != 25002 {
+		t.Errorf("cash should use the global RoundUp: got %d, want 25002", cents)
+	}
+	if cents, _ := crops.DueMinorUnits("USD"); cents != 25001 {
+		t.Errorf("agriculture should use its RoundDown override: got %d, want 25001", cents)
+	}
+	if mode := config.RoundingFor(AssetTypeGold); mode != RoundUp {
+		t.Errorf("unlisted types should fall back to PaymentRounding, got %q", mode)
+	}
+
+	config.RoundingByAssetType = map[string]Rounding{"crops": RoundDown}
+	if _, err := config.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for a non-canonical asset type, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package zakat
+
+import "github.com/shopspring/decimal"
+
+// SuggestSadaqah returns a voluntary sadaqah amount as a fraction of the
+// result's net assets. The fraction is from "0" to "1" or a percent with a
+// trailing "%", so "0.01" and "1%" both suggest 1%.
+//
+// The suggestion is purely voluntary and never part of ZakatDue: it is not an
+// obligation and does not discharge or reduce any zakat owed. Invalid or
+// negative fractions suggest nothing.
+func SuggestSadaqah(result ZakatResult, fraction string) decimal.Decimal {
+	f, err := parseFraction("fraction", fraction)
+	if err != nil {
+		return decimal.Zero
+	}
+	return result.NetAssetsDecimal().Mul(f)
+}
@@ -0,0 +1,23 @@
+package zakat
+
+import "testing"
+
+func TestSuggestSadaqahOnePercent(t *testing.T) {
+	result, err := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	suggestion := SuggestSadaqah(result, "0.01")
+	assertDecimalEqual(t, suggestion.String(), "100", "sadaqah suggestion mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "250", "suggestion must not change zakat due")
+	assertDecimalEqual(t, SuggestSadaqah(result, "1%").String(), "100", "percent form mismatch")
+}
+
+func TestSuggestSadaqahInvalidFraction(t *testing.T) {
+	result := ZakatResult{NetAssets: "10000"}
+	for _, fraction := range []string{"abc", "-0.01"} {
+		if got := SuggestSadaqah(result, fraction); !got.IsZero() {
+			t.Errorf("fraction %q: expected zero, got %s", fraction, got)
+		}
+	}
+}
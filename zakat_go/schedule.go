@@ -0,0 +1,124 @@
+package zakat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ScheduledPayment is one planned payment of a recurring plan.
+type ScheduledPayment struct {
+	// Number - 1-based position of the payment in the plan
+	Number int
+	// Date - the planned payment date
+	Date time.Time
+	// Amount - amount to pay (string for precision)
+	Amount string
+}
+
+// PaymentSchedule is a plan of payments in date order, as generated by
+// RecurringPlan.
+type PaymentSchedule []ScheduledPayment
+
+// RecurringPlan splits an estimated annual zakat into evenly spaced monthly
+// payments starting at startDate.
+//
+// This is forward planning from an estimate, not installment tracking. Amounts
+// are split to the minor unit of currency (whole yen, cents, fils); leftover
+// units go one each to the earliest payments and any smaller remainder to
+// the last payment, so the plan always sums to annualDue exactly. Payment
+// dates that fall past the end of a shorter month are clamped to its last
+// day. Returns ErrInvalidOption for an unknown currency.
+func RecurringPlan(annualDue, currency string, startDate time.Time, payments int) (PaymentSchedule, error) {
+	places, ok := currencyDecimals[strings.ToUpper(strings.TrimSpace(currency))]
+	if !ok {
+		return nil, fieldError(ErrInvalidOption, "currency", currency)
+	}
+	if payments <= 0 {
+		return nil, fieldError(ErrInvalidSchedule, "payments", strconv.Itoa(payments))
+	}
+	due, err := parseAmount("annual_due", annualDue)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]decimal.Decimal, payments)
+	for i := range weights {
+		weights[i] = decimal.NewFromInt(1)
+	}
+	amounts := splitExact(due, weights, places)
+
+	plan := make(PaymentSchedule, payments)
+	for i := range plan {
+		plan[i] = ScheduledPayment{
+			Number: i + 1,
+			Date:   addMonthsClamped(startDate, i),
+			Amount: amounts[i].String(),
+		}
+	}
+	return plan, nil
+}
+
+// PlanStatus summarizes a payment schedule as of a date.
+type PlanStatus struct {
+	// Overdue - payments dated before the as-of day
+	Overdue int
+	// DueToday - payments dated on the as-of day
+	DueToday int
+	// Remaining - payments dated after the as-of day
+	Remaining int
+	// RemainingAmount - total of the remaining payments (string for precision)
+	RemainingAmount string
+	// NextDate - date of the first remaining payment; zero if none remain
+	NextDate time.Time
+	// DaysUntilNext - calendar days from the as-of day to NextDate
+	DaysUntilNext int
+}
+
+// Status reports where the schedule stands on asOf's calendar day, for
+// payment reminders. It works on the schedule alone and does not know which
+// payments were made: "overdue" means the date has passed.
+func (s PaymentSchedule) Status(asOf time.Time) PlanStatus {
+	status := PlanStatus{}
+	remaining := decimal.Zero
+	for _, payment := range s {
+		today := calendarDay(asOf.In(payment.Date.Location()))
+		day := calendarDay(payment.Date)
+		switch {
+		case day.Before(today):
+			status.Overdue++
+		case day.Equal(today):
+			status.DueToday++
+		default:
+			if status.Remaining == 0 {
+				status.NextDate = payment.Date
+				status.DaysUntilNext = int(day.Sub(today).Hours() / 24)
+			}
+			status.Remaining++
+			remaining = remaining.Add(ToDecimal(payment.Amount))
+		}
+	}
+	status.RemainingAmount = remaining.String()
+	return status
+}
+
+// calendarDay returns t's date at midnight UTC, for whole-day arithmetic
+// free of time-zone offsets and daylight-saving shifts.
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// addMonthsClamped adds months to t, clamping the day to the end of the
+// target month instead of overflowing into the next one.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := first.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
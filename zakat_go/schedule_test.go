@@ -0,0 +1,109 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRecurringPlanSumsExactly(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	plan, err := RecurringPlan("1000", "USD", start, 12)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if len(plan) != 12 {
+		t.Fatalf("expected 12 payments, got %d", len(plan))
+	}
+
+	sum := decimal.Zero
+	for _, p := range plan {
+		sum = sum.Add(ToDecimal(p.Amount))
+	}
+	if !sum.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("payments sum to %s, want 1000", sum)
+	}
+	// 1000 / 12 = 83.33 with 4 cents left over for the first payments.
+	if plan[0].Amount != "83.34" || plan[3].Amount != "83.34" || plan[4].Amount != "83.33" {
+		t.Errorf("unexpected remainder allocation: %s %s %s", plan[0].Amount, plan[3].Amount, plan[4].Amount)
+	}
+	if got := plan[1].Date; !got.Equal(time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("february payment should clamp to month end, got %s", got)
+	}
+	if got := plan[11].Date; !got.Equal(time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("last payment date mismatch, got %s", got)
+	}
+}
+
+func TestRecurringPlanSubCentRemainder(t *testing.T) {
+	plan, err := RecurringPlan("212.475", "USD", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), 12)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	sum := decimal.Zero
+	for _, p := range plan {
+		sum = sum.Add(ToDecimal(p.Amount))
+	}
+	if !sum.Equal(decimal.RequireFromString("212.475")) {
+		t.Errorf("payments sum to %s, want 212.475", sum)
+	}
+}
+
+func TestRecurringPlanInvalid(t *testing.T) {
+	if _, err := RecurringPlan("1000", "USD", time.Now(), 0); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+	if _, err := RecurringPlan("-5", "USD", time.Now(), 12); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("expected ErrNegativeValue, got %v", err)
+	}
+	if _, err := RecurringPlan("1000", "XYZ", time.Now(), 12); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption for an unknown currency, got %v", err)
+	}
+}
+
+func TestRecurringPlanWholeYen(t *testing.T) {
+	plan, err := RecurringPlan("10000", "JPY", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), 3)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	for i, want := range []string{"3334", "3333", "3333"} {
+		assertDecimalEqual(t, plan[i].Amount, want, "yen payment")
+	}
+}
+
+func TestPaymentScheduleStatus(t *testing.T) {
+	start := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	plan, err := RecurringPlan("1200", "USD", start, 12)
+	if err != nil {
+		t.Fatalf("RecurringPlan failed: %v", err)
+	}
+
+	tests := []struct {
+		name                         string
+		asOf                         time.Time
+		overdue, dueToday, remaining int
+		amount                       string
+		daysUntilNext                int
+	}{
+		{"before the first", time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), 0, 0, 12, "1200", 5},
+		{"on an installment", time.Date(2025, 3, 15, 18, 0, 0, 0, time.UTC), 2, 1, 9, "900", 31},
+		{"between installments", time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), 3, 0, 9, "900", 26},
+		{"after the last", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 12, 0, 0, "0", 0},
+	}
+	for _, tt := range tests {
+		status := plan.Status(tt.asOf)
+		if status.Overdue != tt.overdue || status.DueToday != tt.dueToday || status.Remaining != tt.remaining {
+			t.Errorf("%s: expected %d overdue, %d due today, %d remaining, got %+v",
+				tt.name, tt.overdue, tt.dueToday, tt.remaining, status)
+		}
+		assertDecimalEqual(t, status.RemainingAmount, tt.amount, tt.name+": remaining amount mismatch")
+		if status.DaysUntilNext != tt.daysUntilNext {
+			t.Errorf("%s: expected %d days until next, got %d", tt.name, tt.daysUntilNext, status.DaysUntilNext)
+		}
+	}
+	if !plan.Status(start).NextDate.Equal(plan[1].Date) {
+		t.Error("next date should be the first payment after the as-of day")
+	}
+}
@@ -0,0 +1,80 @@
+package zakat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrSelfTestFailed is returned by SelfTest when a known-answer check fails.
+var ErrSelfTestFailed = errors.New("zakat: self-test failed")
+
+// selfTestTolerance matches the tolerance of DecimalEqual.
+var selfTestTolerance = decimal.New(1, -7)
+
+// selfTestCase is one known-answer calculation.
+type selfTestCase struct {
+	name      string
+	input     any
+	isPayable bool
+	zakatDue  string
+}
+
+// selfTestCases are known answers at gold 100/g and silver 1/g.
+var selfTestCases = []selfTestCase{
+	{"business-above-nisab", BusinessInput{CashOnHand: "10000", HawlSatisfied: true}, true, "250"},
+	{"business-below-nisab", BusinessInput{CashOnHand: "500", HawlSatisfied: true}, false, "0"},
+	{"cash-net-of-liabilities", CashInput{CashOnHand: "12000", Liabilities: "2000", HawlSatisfied: true}, true, "250"},
+	{"gold-investment-24k", GoldInput{WeightGrams: "100", Purity: "24", Usage: "Investment", HawlSatisfied: true}, true, "250"},
+	{"silver-investment-999", SilverInput{WeightGrams: "1000", Purity: "999", Usage: "Investment", HawlSatisfied: true}, true, "24.975"},
+}
+
+// SelfTest runs a handful of known-answer calculations through the
+// calculation backend and checks the results within tolerance. The cases run
+// through the Rust library when FFIAvailable reports true, and through the
+// pure-Go port otherwise.
+//
+// Call it at startup to catch a missing or incompatible backend early. The
+// returned error wraps ErrSelfTestFailed, names the backend tested ("ffi" or
+// "go") and every check that failed.
+func SelfTest() error {
+	config := NewConfig("100", "1")
+	backend, calculate := "go", calculateInput
+	if ffiCalculate != nil && FFIAvailable() {
+		backend, calculate = "ffi", func(input any, config Config) (ZakatResult, error) {
+			ffiMu.Lock()
+			defer ffiMu.Unlock()
+			return ffiCalculate(input, config)
+		}
+	}
+	var failures []error
+	for _, tc := range selfTestCases {
+		if err := tc.run(calculate, config); err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", tc.name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%w (%s backend): %w", ErrSelfTestFailed, backend, errors.Join(failures...))
+	}
+	return nil
+}
+
+// run calculates the case and compares it with the known answer.
+func (tc selfTestCase) run(calculate func(any, Config) (ZakatResult, error), config Config) error {
+	result, err := calculate(tc.input, config)
+	if err != nil {
+		return err
+	}
+	if result.IsPayable != tc.isPayable {
+		return fmt.Errorf("is_payable = %t, want %t", result.IsPayable, tc.isPayable)
+	}
+	due, err := decimal.NewFromString(result.ZakatDue)
+	if err != nil {
+		return fmt.Errorf("zakat_due %q is not a decimal", result.ZakatDue)
+	}
+	if due.Sub(decimal.RequireFromString(tc.zakatDue)).Abs().GreaterThan(selfTestTolerance) {
+		return fmt.Errorf("zakat_due = %s, want %s", result.ZakatDue, tc.zakatDue)
+	}
+	return nil
+}
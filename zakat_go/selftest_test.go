@@ -0,0 +1,43 @@
+package zakat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelfTestPasses(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatalf("SelfTest failed: %v", err)
+	}
+}
+
+func TestSelfTestReportsFailedCheck(t *testing.T) {
+	saved := selfTestCases
+	t.Cleanup(func() { selfTestCases = saved })
+	selfTestCases = append([]selfTestCase{{"wrong-answer", BusinessInput{CashOnHand: "10000", HawlSatisfied: true}, true, "999"}}, saved...)
+
+	err := SelfTest()
+	if !errors.Is(err, ErrSelfTestFailed) {
+		t.Fatalf("expected ErrSelfTestFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "wrong-answer") {
+		t.Errorf("error should name the failed check and backend, got %v", err)
+	}
+}
+
+func TestSelfTestRunsOnFFIBackend(t *testing.T) {
+	var calls int
+	withFFIBackend(t, func(input any, config Config) (ZakatResult, error) {
+		calls++
+		return ZakatResult{ZakatDue: "1"}, nil
+	})
+
+	err := SelfTest()
+	if calls != len(selfTestCases) {
+		t.Errorf("expected every case to run through the FFI backend, got %d of %d", calls, len(selfTestCases))
+	}
+	if !errors.Is(err, ErrSelfTestFailed) || !strings.Contains(err.Error(), "ffi backend") {
+		t.Errorf("error should name the ffi backend, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package signing issues and verifies tamper-evident zakat certificates: a
+// ZakatResult signed with HMAC-SHA256 as a compact token.
+//
+// Tokens use the JWS compact serialization (RFC 7515) with alg HS256, so
+// they are three base64url segments joined by dots: header, the result as
+// JSON, and the signature. Anyone holding the key can verify a token; it is
+// not encrypted, so the result is readable without the key.
+//
+// It lives outside package zakat to keep key handling and token formats out
+// of the calculation API.
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	zakat "github.com/IRedDragonICY/zakatrs/zakat_go"
+)
+
+var (
+	// ErrEmptyKey is returned when signing or verifying with an empty key.
+	ErrEmptyKey = errors.New("signing: empty key")
+	// ErrMalformedToken is returned when a token is not a well-formed HS256 token.
+	ErrMalformedToken = errors.New("signing: malformed token")
+	// ErrInvalidSignature is returned when a token's signature does not match
+	// its content, because it was altered or signed with another key.
+	ErrInvalidSignature = errors.New("signing: invalid signature")
+)
+
+// header is the fixed JWS header of every token.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// SignResult returns result as a signed token. The result is encoded as the
+// canonical JSON of encoding/json; ConfigSnapshot.NisabResolver is code, not
+// data, and is dropped.
+func SignResult(result zakat.ZakatResult, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+	result.ConfigSnapshot.NisabResolver = nil
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("signing: encode result: %w", err)
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + sign(signingInput, key), nil
+}
+
+// VerifyResult checks token's signature with key and returns the signed
+// result. Any change to the token, or a different key, yields
+// ErrInvalidSignature.
+func VerifyResult(token string, key []byte) (zakat.ZakatResult, error) {
+	if len(key) == 0 {
+		return zakat.ZakatResult{}, ErrEmptyKey
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return zakat.ZakatResult{}, ErrMalformedToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return zakat.ZakatResult{}, ErrMalformedToken
+	}
+	want, _ := base64.RawURLEncoding.DecodeString(sign(parts[0]+"."+parts[1], key))
+	if !hmac.Equal(got, want) {
+		return zakat.ZakatResult{}, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return zakat.ZakatResult{}, ErrMalformedToken
+	}
+	var result zakat.ZakatResult
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return zakat.ZakatResult{}, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	return result, nil
+}
+
+func sign(signingInput string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,69 @@
+package signing
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	zakat "github.com/IRedDragonICY/zakatrs/zakat_go"
+)
+
+func calculated(t *testing.T) zakat.ZakatResult {
+	t.Helper()
+	result, err := zakat.Calculate("cash", zakat.CashInput{CashOnHand: "10000", HawlSatisfied: true}, zakat.NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	return result
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	result := calculated(t)
+	key := []byte("charity-secret")
+
+	token, err := SignResult(result, key)
+	if err != nil {
+		t.Fatalf("SignResult failed: %v", err)
+	}
+	if strings.Count(token, ".") != 2 {
+		t.Errorf("expected a three-segment token, got %q", token)
+	}
+	verified, err := VerifyResult(token, key)
+	if err != nil {
+		t.Fatalf("VerifyResult failed: %v", err)
+	}
+	if !reflect.DeepEqual(verified, result) {
+		t.Errorf("round trip changed the result:\ngot  %+v\nwant %+v", verified, result)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	key := []byte("charity-secret")
+	token, err := SignResult(calculated(t), key)
+	if err != nil {
+		t.Fatalf("SignResult failed: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	forged := strings.Replace(string(payload), `"ZakatDue":"250"`, `"ZakatDue":"25"`, 1)
+	if forged == string(payload) {
+		t.Fatal("test payload did not contain the expected due")
+	}
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(forged))
+
+	if _, err := VerifyResult(strings.Join(parts, "."), key); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("altered payload: expected ErrInvalidSignature, got %v", err)
+	}
+	if _, err := VerifyResult(token, []byte("other-key")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong key: expected ErrInvalidSignature, got %v", err)
+	}
+	if _, err := VerifyResult("not-a-token", key); !errors.Is(err, ErrMalformedToken) {
+		t.Errorf("expected ErrMalformedToken, got %v", err)
+	}
+	if _, err := SignResult(zakat.ZakatResult{}, nil); !errors.Is(err, ErrEmptyKey) {
+		t.Errorf("expected ErrEmptyKey, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package zakat
+
+import (
+	"sort"
+	"time"
+)
+
+// Snapshot is the owner's net zakatable wealth at one point in time.
+type Snapshot struct {
+	// At - when the snapshot was taken
+	At time.Time
+	// NetAssets - net zakatable wealth at that time (string for precision)
+	NetAssets string
+}
+
+// SnapshotSeries is a history of wealth snapshots, the basis of the "stayed
+// above nisab" and hawl-crossing rulings. Snapshots may be in any order;
+// the methods consider them chronologically, and invalid amounts count as
+// zero.
+type SnapshotSeries []Snapshot
+
+// sorted returns a chronological copy of the series.
+func (s SnapshotSeries) sorted() SnapshotSeries {
+	sorted := append(SnapshotSeries(nil), s...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+	return sorted
+}
+
+// Min returns the snapshot with the lowest net assets, the earliest on a
+// tie. It reports false for an empty series.
+func (s SnapshotSeries) Min() (Snapshot, bool) {
+	sorted := s.sorted()
+	if len(sorted) == 0 {
+		return Snapshot{}, false
+	}
+	minimum := sorted[0]
+	for _, snapshot := range sorted[1:] {
+		if ToDecimal(snapshot.NetAssets).LessThan(ToDecimal(minimum.NetAssets)) {
+			minimum = snapshot
+		}
+	}
+	return minimum, true
+}
+
+// FirstAbove returns the earliest snapshot at or above the nisab, when
+// wealth first reached it. It reports false if no snapshot does.
+func (s SnapshotSeries) FirstAbove(nisab string) (Snapshot, bool) {
+	threshold := ToDecimal(nisab)
+	for _, snapshot := range s.sorted() {
+		if meetsNisab(ToDecimal(snapshot.NetAssets), threshold) {
+			return snapshot, true
+		}
+	}
+	return Snapshot{}, false
+}
+
+// AlwaysAbove reports whether every snapshot is at or above the nisab, the
+// view that wealth must not dip below it during the hawl. An empty series
+// is not above the nisab.
+func (s SnapshotSeries) AlwaysAbove(nisab string) bool {
+	minimum, ok := s.Min()
+	return ok && meetsNisab(ToDecimal(minimum.NetAssets), ToDecimal(nisab))
+}
+
+// HawlStartOnNisabCrossing returns the date the hawl started: when wealth
+// reached the nisab. Wealth held below the nisab does not start a hawl.
+//
+// A dip below the nisab interrupts the hawl (the majority view), so if
+// wealth fell back below the nisab and crossed it again, the later crossing
+// is returned. It reports false if the latest snapshot is below the nisab.
+// Snapshots may be in any order; invalid amounts count as zero.
+func HawlStartOnNisabCrossing(history []Snapshot, nisab string) (time.Time, bool) {
+	threshold := ToDecimal(nisab)
+	sorted := SnapshotSeries(history).sorted()
+
+	var start time.Time
+	above := false
+	for _, snapshot := range sorted {
+		if meetsNisab(ToDecimal(snapshot.NetAssets), threshold) {
+			if !above {
+				start, above = snapshot.At, true
+			}
+		} else {
+			above = false
+		}
+	}
+	if !above {
+		return time.Time{}, false
+	}
+	return start, true
+}
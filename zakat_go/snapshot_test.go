@@ -0,0 +1,83 @@
+package zakat
+
+import (
+	"testing"
+	"time"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHawlStartOnNisabCrossing(t *testing.T) {
+	history := []Snapshot{
+		{At: day(time.January, 1), NetAssets: "200"},
+		{At: day(time.February, 1), NetAssets: "450"},
+		{At: day(time.March, 1), NetAssets: "600"},
+		{At: day(time.April, 1), NetAssets: "900"},
+	}
+	start, ok := HawlStartOnNisabCrossing(history, "595")
+	if !ok {
+		t.Fatalf("expected a hawl start")
+	}
+	if !start.Equal(day(time.March, 1)) {
+		t.Errorf("hawl should start when wealth reached nisab, got %s", start)
+	}
+}
+
+func TestHawlStartRestartsAfterDip(t *testing.T) {
+	history := []Snapshot{
+		{At: day(time.May, 1), NetAssets: "700"},
+		{At: day(time.January, 1), NetAssets: "800"},
+		{At: day(time.March, 1), NetAssets: "100"},
+	}
+	start, ok := HawlStartOnNisabCrossing(history, "595")
+	if !ok || !start.Equal(day(time.May, 1)) {
+		t.Errorf("a dip below nisab should restart the hawl, got %s %t", start, ok)
+	}
+
+	if _, ok := HawlStartOnNisabCrossing(history[2:], "595"); ok {
+		t.Errorf("wealth below nisab should not start a hawl")
+	}
+}
+
+func TestSnapshotSeriesDipsBelowNisab(t *testing.T) {
+	series := SnapshotSeries{
+		{At: day(time.June, 1), NetAssets: "9000"},
+		{At: day(time.January, 1), NetAssets: "500"},
+		{At: day(time.March, 1), NetAssets: "7000"},
+		{At: day(time.April, 1), NetAssets: "400"},
+	}
+	minimum, ok := series.Min()
+	if !ok || minimum.NetAssets != "400" {
+		t.Errorf("expected the April low of 400, got %+v", minimum)
+	}
+	first, ok := series.FirstAbove("5950")
+	if !ok || !first.At.Equal(day(time.March, 1)) {
+		t.Errorf("expected the March crossing, got %+v", first)
+	}
+	if series.AlwaysAbove("5950") {
+		t.Error("a series dipping to 400 is not always above the nisab")
+	}
+	if _, ok := series.FirstAbove("10000"); ok {
+		t.Error("no snapshot reaches 10000")
+	}
+}
+
+func TestSnapshotSeriesStaysAboveNisab(t *testing.T) {
+	series := SnapshotSeries{
+		{At: day(time.January, 1), NetAssets: "8000"},
+		{At: day(time.May, 1), NetAssets: "6000"},
+		{At: day(time.September, 1), NetAssets: "12000"},
+	}
+	if !series.AlwaysAbove("5950") {
+		t.Error("a series never below 6000 stays above a 5950 nisab")
+	}
+	first, _ := series.FirstAbove("5950")
+	if !first.At.Equal(day(time.January, 1)) {
+		t.Errorf("expected the first snapshot, got %+v", first)
+	}
+	if SnapshotSeries(nil).AlwaysAbove("0") {
+		t.Error("an empty series is not above the nisab")
+	}
+}
@@ -0,0 +1,74 @@
+package zakat
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// splitPlaces returns the minor-unit places splits are made to, those of
+// Config.BaseCurrency. See minorUnitPlaces.
+func (c Config) splitPlaces() int32 {
+	return minorUnitPlaces(c.BaseCurrency)
+}
+
+// splitExact splits total in proportion to weights, to the minor unit of
+// places decimal places (0 for JPY, 2 for cents, 3 for fils), so that the
+// parts always sum to total exactly.
+//
+// Each part first gets its proportional share rounded down to the minor
+// unit; the units left over go one each to the parts with the largest
+// remainders (largest-remainder allocation), earlier parts winning ties. Any
+// remainder below the minor unit goes to the last part with a positive
+// weight. Weights must be non-negative; if none is positive, total is split
+// evenly.
+func splitExact(total decimal.Decimal, weights []decimal.Decimal, places int32) []decimal.Decimal {
+	parts := make([]decimal.Decimal, len(weights))
+	if len(weights) == 0 {
+		return parts
+	}
+	sum := decimal.Zero
+	for _, w := range weights {
+		sum = sum.Add(w)
+	}
+	if !sum.IsPositive() {
+		weights = make([]decimal.Decimal, len(weights))
+		for i := range weights {
+			weights[i] = decimal.NewFromInt(1)
+		}
+		sum = decimal.NewFromInt(int64(len(weights)))
+	}
+
+	minorUnit := decimal.New(1, -places)
+	whole := total.Truncate(places)
+	units := whole.Div(minorUnit)
+	remainders := make([]decimal.Decimal, len(weights))
+	allocated := decimal.Zero
+	for i, w := range weights {
+		// Multiply before dividing so shares that divide evenly stay exact.
+		share := units.Mul(w).Div(sum)
+		parts[i] = share.Floor()
+		remainders[i] = share.Sub(parts[i])
+		allocated = allocated.Add(parts[i])
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]].GreaterThan(remainders[order[b]]) })
+	leftover := units.Sub(allocated).IntPart()
+	for _, i := range order[:leftover] {
+		parts[i] = parts[i].Add(decimal.NewFromInt(1))
+	}
+
+	last := len(weights) - 1
+	for last > 0 && !weights[last].IsPositive() {
+		last--
+	}
+	for i := range parts {
+		parts[i] = parts[i].Mul(minorUnit)
+	}
+	parts[last] = parts[last].Add(total.Sub(whole))
+	return parts
+}
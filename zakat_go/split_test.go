@@ -0,0 +1,80 @@
+package zakat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func decimals(values ...string) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		out[i] = decimal.RequireFromString(v)
+	}
+	return out
+}
+
+func TestSplitExactSumsToTotal(t *testing.T) {
+	tests := []struct {
+		total   string
+		weights []string
+		want    []string
+	}{
+		// 100 / 3: 33.333... each, the spare cent to the first.
+		{"100", []string{"1", "1", "1"}, []string{"33.34", "33.33", "33.33"}},
+		// Remainders .666, .333, .0: the largest remainder takes the spare cent.
+		{"10", []string{"2", "1", "0"}, []string{"6.67", "3.33", "0"}},
+		// 1 split 1/7 : 6/7 = 0.142857 : 0.857142.
+		{"1", []string{"1", "6"}, []string{"0.14", "0.86"}},
+		// Sub-cent residue lands on the last weighted part.
+		{"10.005", []string{"1", "1", "0"}, []string{"5", "5.005", "0"}},
+		// No positive weight splits evenly.
+		{"1", []string{"0", "0"}, []string{"0.5", "0.5"}},
+	}
+	for _, tt := range tests {
+		parts := splitExact(decimal.RequireFromString(tt.total), decimals(tt.weights...), 2)
+		sum := decimal.Zero
+		for i, part := range parts {
+			sum = sum.Add(part)
+			assertDecimalEqual(t, part.String(), tt.want[i], "split of "+tt.total)
+		}
+		if !sum.Equal(decimal.RequireFromString(tt.total)) {
+			t.Errorf("split of %s by %v sums to %s", tt.total, tt.weights, sum)
+		}
+	}
+}
+
+func TestSplitExactManyUnevenWeights(t *testing.T) {
+	total := decimal.RequireFromString("999.99")
+	weights := decimals("3", "7", "11", "13", "17", "19", "23")
+	sum := decimal.Zero
+	for _, part := range splitExact(total, weights, 2) {
+		if !part.Equal(part.Truncate(2)) {
+			t.Errorf("part %s is not whole cents", part)
+		}
+		sum = sum.Add(part)
+	}
+	if !sum.Equal(total) {
+		t.Errorf("parts sum to %s, want %s", sum, total)
+	}
+}
+
+func TestSplitExactCurrencyMinorUnit(t *testing.T) {
+	tests := []struct {
+		total  string
+		places int32
+		want   []string
+	}{
+		// Yen have no minor unit: 100 / 3 in whole yen.
+		{"100", 0, []string{"34", "33", "33"}},
+		// Dinar split to the fils.
+		{"100", 3, []string{"33.334", "33.333", "33.333"}},
+	}
+	for _, tt := range tests {
+		parts := splitExact(decimal.RequireFromString(tt.total), decimals("1", "1", "1"), tt.places)
+		for i, part := range parts {
+			assertDecimalEqual(t, part.String(), tt.want[i], fmt.Sprintf("split to %d places", tt.places))
+		}
+	}
+}
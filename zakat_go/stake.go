@@ -0,0 +1,85 @@
+package zakat
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StakeChange records a part-owner's share of a business changing at a
+// point in time, for example when selling out to a partner.
+type StakeChange struct {
+	// FromFraction - the share held before the change, from "0" to "1"
+	// (percent forms like "50%" are accepted)
+	FromFraction string
+	// ToFraction - the share held from At onwards
+	ToFraction string
+	// At - when the change took effect
+	At time.Time
+}
+
+// TimeWeightedStake averages a part-owner's stake over the hawl from start
+// to end, weighting each share by how long it was held. Multiplying the
+// business's net zakatable assets by it estimates the owner's portion.
+//
+// It is an estimation aid only: scholars differ on how a stake that
+// changed mid-year is assessed, and many simply use the stake held on the
+// zakat date. Changes may be in any order but must fall within the period
+// and chain, each FromFraction matching the previous ToFraction.
+func TimeWeightedStake(start, end time.Time, changes []StakeChange) (string, error) {
+	if !end.After(start) {
+		return "", fmt.Errorf("%w: period end %s is not after start %s", ErrInconsistentInput,
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	if len(changes) == 0 {
+		return "", fieldError(ErrInconsistentInput, "changes", "")
+	}
+	sorted := append([]StakeChange(nil), changes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	stake, err := parseStake("from_fraction", sorted[0].FromFraction)
+	if err != nil {
+		return "", err
+	}
+	weighted := decimal.Zero
+	held := start
+	for i, change := range sorted {
+		if change.At.Before(start) || change.At.After(end) {
+			return "", fieldError(ErrInconsistentInput, fmt.Sprintf("changes[%d].at", i), change.At.Format(time.RFC3339))
+		}
+		from, err := parseStake(fmt.Sprintf("changes[%d].from_fraction", i), change.FromFraction)
+		if err != nil {
+			return "", err
+		}
+		if !from.Equal(stake) {
+			return "", fmt.Errorf("%w: changes[%d].from_fraction=%q does not match the previous stake %s",
+				ErrInconsistentInput, i, change.FromFraction, stake)
+		}
+		to, err := parseStake(fmt.Sprintf("changes[%d].to_fraction", i), change.ToFraction)
+		if err != nil {
+			return "", err
+		}
+		weighted = weighted.Add(stake.Mul(durationDecimal(change.At.Sub(held))))
+		stake, held = to, change.At
+	}
+	weighted = weighted.Add(stake.Mul(durationDecimal(end.Sub(held))))
+	return weighted.Div(durationDecimal(end.Sub(start))).String(), nil
+}
+
+// parseStake parses a required ownership fraction between 0 and 1.
+func parseStake(field, s string) (decimal.Decimal, error) {
+	d, err := parseFractionField(field, s)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if d.GreaterThan(decimal.NewFromInt(1)) {
+		return decimal.Zero, fieldError(ErrInvalidFraction, field, s)
+	}
+	return d, nil
+}
+
+func durationDecimal(d time.Duration) decimal.Decimal {
+	return decimal.NewFromInt(int64(d))
+}
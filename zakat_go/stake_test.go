@@ -0,0 +1,58 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeWeightedStakeMidYearSale(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 100)
+
+	// 50% for 40 days, then 20% for 60 days: (0.5*40 + 0.2*60) / 100 = 0.32.
+	stake, err := TimeWeightedStake(start, end, []StakeChange{
+		{FromFraction: "0.5", ToFraction: "0.2", At: start.AddDate(0, 0, 40)},
+	})
+	if err != nil {
+		t.Fatalf("TimeWeightedStake failed: %v", err)
+	}
+	assertDecimalEqual(t, stake, "0.32", "time-weighted stake mismatch")
+
+	// Two chained changes given out of order: 60% x 25, 40% x 25, 0% x 50.
+	stake, err = TimeWeightedStake(start, end, []StakeChange{
+		{FromFraction: "40%", ToFraction: "0", At: start.AddDate(0, 0, 50)},
+		{FromFraction: "60%", ToFraction: "40%", At: start.AddDate(0, 0, 25)},
+	})
+	if err != nil {
+		t.Fatalf("TimeWeightedStake failed: %v", err)
+	}
+	assertDecimalEqual(t, stake, "0.25", "chained stake mismatch")
+}
+
+func TestTimeWeightedStakeInvalid(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 100)
+	mid := start.AddDate(0, 0, 50)
+
+	tests := []struct {
+		name    string
+		end     time.Time
+		changes []StakeChange
+		want    error
+	}{
+		{"no changes", end, nil, ErrInconsistentInput},
+		{"empty period", start, []StakeChange{{FromFraction: "1", ToFraction: "0", At: start}}, ErrInconsistentInput},
+		{"outside period", end, []StakeChange{{FromFraction: "1", ToFraction: "0", At: end.AddDate(0, 0, 1)}}, ErrInconsistentInput},
+		{"broken chain", end, []StakeChange{
+			{FromFraction: "1", ToFraction: "0.5", At: mid},
+			{FromFraction: "0.4", ToFraction: "0", At: mid.AddDate(0, 0, 1)},
+		}, ErrInconsistentInput},
+		{"above one", end, []StakeChange{{FromFraction: "150%", ToFraction: "0", At: mid}}, ErrInvalidFraction},
+	}
+	for _, tt := range tests {
+		if _, err := TimeWeightedStake(start, tt.end, tt.changes); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
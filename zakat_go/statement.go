@@ -0,0 +1,148 @@
+package zakat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statement is a consolidated annual zakat statement for one payer,
+// assembled from the results of each asset type.
+type Statement struct {
+	// ID - stable identifier derived from the year, payer and dues
+	ID string
+	// Year - the zakat year the statement covers
+	Year int
+	// Payer - name or identifier of the payer
+	Payer string
+	// TotalDue - total zakat due across all results (string for precision)
+	TotalDue string
+	// ByAssetType - zakat due per asset type (strings for precision)
+	ByAssetType map[string]string
+	// Currency - the results' common Config.BaseCurrency, empty when they
+	// name none or differ. Reconcile rounds to its minor unit.
+	Currency string
+	// Results - the results the statement was assembled from
+	Results []ZakatResult
+}
+
+// NewStatement assembles a statement from results for a year and payer.
+// Dues of results with the same asset type are summed.
+//
+// The ID is the SHA-256 of the year, payer and per-asset-type dues in a
+// canonical order, so reassembling the same figures yields the same ID while
+// any change in the amounts yields a different one. Use StatementID for an
+// identifier that survives recomputation.
+func NewStatement(year int, payer string, results ...ZakatResult) Statement {
+	total := decimal.Zero
+	byType := make(map[string]decimal.Decimal)
+	currency := ""
+	for i, result := range results {
+		if i == 0 {
+			currency = result.ConfigSnapshot.BaseCurrency
+		} else if !strings.EqualFold(strings.TrimSpace(currency), strings.TrimSpace(result.ConfigSnapshot.BaseCurrency)) {
+			currency = ""
+		}
+		due := result.ZakatDueDecimal()
+		total = total.Add(due)
+		byType[result.AssetType] = byType[result.AssetType].Add(due)
+	}
+
+	types := make([]string, 0, len(byType))
+	byAssetType := make(map[string]string, len(byType))
+	for assetType, due := range byType {
+		types = append(types, assetType)
+		byAssetType[assetType] = due.String()
+	}
+	sort.Strings(types)
+
+	var canonical strings.Builder
+	canonical.WriteString(strconv.Itoa(year) + "\n" + payer + "\n")
+	for _, assetType := range types {
+		canonical.WriteString(assetType + "=" + byType[assetType].String() + "\n")
+	}
+	sum := sha256.Sum256([]byte(canonical.String()))
+
+	return Statement{
+		ID:          "stmt-" + hex.EncodeToString(sum[:16]),
+		Year:        year,
+		Payer:       payer,
+		TotalDue:    total.String(),
+		ByAssetType: byAssetType,
+		Currency:    currency,
+		Results:     results,
+	}
+}
+
+// StatementID returns a stable identifier for a payer's statement over the
+// period starting at period. Unlike Statement.ID, which changes whenever
+// any amount does, it depends only on the payer and the period's calendar
+// date (in UTC), so a corrected or recomputed statement keeps the same
+// StatementID and can be reconciled against the one it replaces.
+func StatementID(payer string, period time.Time) string {
+	sum := sha256.Sum256([]byte(period.UTC().Format("2006-01-02") + "\n" + payer + "\n"))
+	return "period-" + hex.EncodeToString(sum[:16])
+}
+
+// ReconcilePolicy decides how Statement.Reconcile squares rounded parts
+// with the rounded total.
+type ReconcilePolicy string
+
+const (
+	// ReconcileTotalOnly rounds the total and each asset type's due
+	// independently. The total is the exact total rounded, but the rounded
+	// parts may not add up to it.
+	ReconcileTotalOnly ReconcilePolicy = "total_only"
+	// ReconcileAbsorbToLargest rounds every part, then moves the rounding
+	// difference onto the asset type with the largest exact due, so the
+	// parts add up to the rounded total exactly.
+	ReconcileAbsorbToLargest ReconcilePolicy = "absorb_to_largest"
+)
+
+// Reconcile returns a copy of the statement with TotalDue and ByAssetType
+// rounded half-up for display to the minor unit of Currency (whole yen,
+// cents, fils; cents when Currency is empty or unknown), squared per policy. The ID and
+// Results are unchanged and still reflect the exact dues.
+func (s Statement) Reconcile(policy ReconcilePolicy) (Statement, error) {
+	switch policy {
+	case ReconcileTotalOnly, ReconcileAbsorbToLargest:
+	default:
+		return Statement{}, fieldError(ErrInvalidOption, "policy", string(policy))
+	}
+
+	types := make([]string, 0, len(s.ByAssetType))
+	for assetType := range s.ByAssetType {
+		types = append(types, assetType)
+	}
+	sort.Strings(types)
+
+	places := minorUnitPlaces(s.Currency)
+	total := ToDecimal(s.TotalDue).Round(places)
+	rounded := make(map[string]decimal.Decimal, len(types))
+	sum := decimal.Zero
+	largest := ""
+	for _, assetType := range types {
+		due := ToDecimal(s.ByAssetType[assetType])
+		rounded[assetType] = due.Round(places)
+		sum = sum.Add(rounded[assetType])
+		if largest == "" || due.GreaterThan(ToDecimal(s.ByAssetType[largest])) {
+			largest = assetType
+		}
+	}
+	if policy == ReconcileAbsorbToLargest && largest != "" {
+		rounded[largest] = rounded[largest].Add(total.Sub(sum))
+	}
+
+	reconciled := s
+	reconciled.TotalDue = total.StringFixed(places)
+	reconciled.ByAssetType = make(map[string]string, len(types))
+	for _, assetType := range types {
+		reconciled.ByAssetType[assetType] = rounded[assetType].StringFixed(places)
+	}
+	return reconciled, nil
+}
@@ -0,0 +1,151 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewStatementBusinessAndGold(t *testing.T) {
+	config := NewConfig("100", "1")
+	business, err := CalculateBusiness(BusinessInput{CashOnHand: "20000", InventoryValue: "10000", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("business failed: %v", err)
+	}
+	gold, err := CalculateGold(GoldInput{WeightGrams: "100", Purity: "24", HawlSatisfied: true}, config)
+	if err != nil {
+		t.Fatalf("gold failed: %v", err)
+	}
+
+	statement := NewStatement(2025, "Ahmad", business, gold)
+	assertDecimalEqual(t, statement.TotalDue, "1000", "total due mismatch")
+	assertDecimalEqual(t, statement.ByAssetType[AssetTypeBusiness], "750", "business due mismatch")
+	assertDecimalEqual(t, statement.ByAssetType[AssetTypeGold], "250", "gold due mismatch")
+	if statement.Year != 2025 || statement.Payer != "Ahmad" {
+		t.Errorf("year/payer mismatch: %d %s", statement.Year, statement.Payer)
+	}
+
+	again := NewStatement(2025, "Ahmad", gold, business)
+	if again.ID != statement.ID {
+		t.Errorf("statement ID should not depend on result order: %s vs %s", again.ID, statement.ID)
+	}
+	if other := NewStatement(2026, "Ahmad", business, gold); other.ID == statement.ID {
+		t.Errorf("statement ID should differ across years")
+	}
+}
+
+func TestNewStatementEmpty(t *testing.T) {
+	statement := NewStatement(2025, "Ahmad")
+	assertDecimalEqual(t, statement.TotalDue, "0", "empty statement total mismatch")
+	if len(statement.ByAssetType) != 0 {
+		t.Errorf("expected no asset types, got %v", statement.ByAssetType)
+	}
+}
+
+func TestStatementIDStable(t *testing.T) {
+	period := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	id := StatementID("Ahmad", period)
+	if again := StatementID("Ahmad", period.Add(6*time.Hour)); again != id {
+		t.Errorf("StatementID should be stable within the period's day: %s vs %s", again, id)
+	}
+	if next := StatementID("Ahmad", period.AddDate(1, 0, 0)); next == id {
+		t.Errorf("StatementID should differ across periods")
+	}
+	if other := StatementID("Fatimah", period); other == id {
+		t.Errorf("StatementID should differ across payers")
+	}
+
+	// Statement.ID follows the amounts; StatementID does not.
+	config := NewConfig("100", "1")
+	low, _ := CalculateCash(CashInput{CashOnHand: "10000", HawlSatisfied: true}, config)
+	high, _ := CalculateCash(CashInput{CashOnHand: "20000", HawlSatisfied: true}, config)
+	if NewStatement(2025, "Ahmad", low).ID == NewStatement(2025, "Ahmad", high).ID {
+		t.Errorf("Statement.ID should change with the amounts")
+	}
+}
+
+func TestStatementReconcile(t *testing.T) {
+	statement := NewStatement(2025, "Ahmad",
+		ZakatResult{AssetType: AssetTypeCash, ZakatDue: "0.125"},
+		ZakatResult{AssetType: AssetTypeGold, ZakatDue: "0.125"},
+		ZakatResult{AssetType: AssetTypeBusiness, ZakatDue: "10.335"},
+	)
+
+	// The exact total 10.585 rounds to 10.59, the rounded parts sum to 10.60.
+	totalOnly, err := statement.Reconcile(ReconcileTotalOnly)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if totalOnly.TotalDue != "10.59" {
+		t.Errorf("expected total 10.59, got %s", totalOnly.TotalDue)
+	}
+	if got := totalOnly.ByAssetType[AssetTypeBusiness]; got != "10.34" {
+		t.Errorf("TotalOnly should round each part independently, got business %s", got)
+	}
+
+	absorbed, err := statement.Reconcile(ReconcileAbsorbToLargest)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if absorbed.TotalDue != "10.59" {
+		t.Errorf("expected total 10.59, got %s", absorbed.TotalDue)
+	}
+	want := map[string]string{AssetTypeCash: "0.13", AssetTypeGold: "0.13", AssetTypeBusiness: "10.33"}
+	sum := "0"
+	for assetType, due := range want {
+		if got := absorbed.ByAssetType[assetType]; got != due {
+			t.Errorf("%s: expected %s, got %s", assetType, due, got)
+		}
+		sum = ToDecimal(sum).Add(ToDecimal(absorbed.ByAssetType[assetType])).String()
+	}
+	assertDecimalEqual(t, sum, absorbed.TotalDue, "parts should sum to the displayed total")
+
+	if statement.ByAssetType[AssetTypeBusiness] != "10.335" || absorbed.ID != statement.ID {
+		t.Errorf("Reconcile should not modify the original statement or its ID")
+	}
+	if _, err := statement.Reconcile("nearest"); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestStatementReconcileCurrencyMinorUnit(t *testing.T) {
+	yen := Config{BaseCurrency: "JPY"}
+	statement := NewStatement(2025, "Ahmad",
+		ZakatResult{AssetType: AssetTypeCash, ZakatDue: "0.5", ConfigSnapshot: yen},
+		ZakatResult{AssetType: AssetTypeGold, ZakatDue: "0.5", ConfigSnapshot: yen},
+		ZakatResult{AssetType: AssetTypeBusiness, ZakatDue: "10.4", ConfigSnapshot: yen},
+	)
+	if statement.Currency != "JPY" {
+		t.Fatalf("expected the results' currency JPY, got %q", statement.Currency)
+	}
+	absorbed, err := statement.Reconcile(ReconcileAbsorbToLargest)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	// The exact total 11.4 rounds to 11 yen; the parts round to 1 + 1 + 10.
+	want := map[string]string{AssetTypeCash: "1", AssetTypeGold: "1", AssetTypeBusiness: "9"}
+	if absorbed.TotalDue != "11" {
+		t.Errorf("expected total 11, got %s", absorbed.TotalDue)
+	}
+	for assetType, due := range want {
+		if got := absorbed.ByAssetType[assetType]; got != due {
+			t.Errorf("%s: expected %s, got %s", assetType, due, got)
+		}
+	}
+
+	dinar := NewStatement(2025, "Ahmad", ZakatResult{AssetType: AssetTypeCash, ZakatDue: "1.2345", ConfigSnapshot: Config{BaseCurrency: "KWD"}})
+	if reconciled, _ := dinar.Reconcile(ReconcileTotalOnly); reconciled.TotalDue != "1.235" {
+		t.Errorf("expected the total to the fils, 1.235, got %s", reconciled.TotalDue)
+	}
+
+	mixed := NewStatement(2025, "Ahmad",
+		ZakatResult{AssetType: AssetTypeCash, ZakatDue: "1.2345", ConfigSnapshot: Config{BaseCurrency: "KWD"}},
+		ZakatResult{AssetType: AssetTypeGold, ZakatDue: "1", ConfigSnapshot: yen},
+	)
+	if mixed.Currency != "" {
+		t.Errorf("results in different currencies should leave Currency empty, got %q", mixed.Currency)
+	}
+	if reconciled, _ := mixed.Reconcile(ReconcileTotalOnly); reconciled.TotalDue != "2.23" {
+		t.Errorf("expected the total to the cent, 2.23, got %s", reconciled.TotalDue)
+	}
+}
@@ -0,0 +1,104 @@
+package zakat
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// StockOptionInput holds vested employee stock options.
+//
+// An option is worth its intrinsic value, (market - strike) x shares: what
+// exercising and selling now would realize. Only options the holder can
+// exercise now are owned wealth; unexercisable ones are deferred like other
+// restricted wealth, and underwater options are worth nothing.
+type StockOptionInput struct {
+	// VestedShares - number of shares the vested options cover
+	VestedShares string
+	// StrikePrice - exercise price per share
+	StrikePrice string
+	// MarketPrice - current market price per share
+	MarketPrice string
+	// Exercisable - whether the options can be exercised now
+	Exercisable bool
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that the share count and prices are valid non-negative
+// decimals.
+func (in StockOptionInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// stockOptionValues holds the parsed fields of a StockOptionInput.
+type stockOptionValues struct {
+	shares, strike, market decimal.Decimal
+}
+
+func (in StockOptionInput) parse() (v stockOptionValues, err error) {
+	if v.shares, err = parseAmount("vested_shares", in.VestedShares); err != nil {
+		return
+	}
+	if v.strike, err = parseAmount("strike_price", in.StrikePrice); err != nil {
+		return
+	}
+	v.market, err = parseAmount("market_price", in.MarketPrice)
+	return
+}
+
+// intrinsic returns the options' in-the-money value, zero when underwater.
+func (v stockOptionValues) intrinsic() decimal.Decimal {
+	return decimal.Max(v.market.Sub(v.strike), decimal.Zero).Mul(v.shares)
+}
+
+// CalculateStockOption calculates zakat on vested stock options. Exercisable,
+// in-the-money options owe 2.5% of their intrinsic value when it meets the
+// monetary nisab. Unexercisable options owe nothing now and report their
+// intrinsic value in DeferredAmount; underwater options are worth nothing.
+func CalculateStockOption(input StockOptionInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	value := v.intrinsic()
+	switch {
+	case !value.IsPositive():
+		result := exemptResult(AssetTypeStockOption, "Out of the money",
+			[]string{fmt.Sprintf("Strike price %s is at or above the market price %s; the options have no intrinsic value.", v.strike, v.market)}, config)
+		result.NisabThreshold = nisab.String()
+		return result, nil
+	case !input.Exercisable:
+		result := exemptResult(AssetTypeStockOption, "Unexercisable options deferred until exercisable",
+			[]string{fmt.Sprintf("Options with intrinsic value %s cannot be exercised yet; zakat is due once they can.", value)}, config)
+		result.NisabThreshold = nisab.String()
+		result.DeferredAmount = value.String()
+		return result, nil
+	}
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   value,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeStockOption,
+		breakdown: []BreakdownLine{
+			quantityLine("step-vested-shares", "Vested Shares", v.shares, OpInfo, UnitCount),
+			amountLine("step-market-price", "Market Price per Share", v.market, OpInfo),
+			amountLine("step-strike-price", "Strike Price per Share", v.strike, OpInfo),
+			amountLine("step-intrinsic-value", "Intrinsic Value", value, OpAdd),
+		},
+		assumptions: []string{"Options valued at intrinsic value, (market - strike) x vested shares."},
+		config:      config,
+	})
+}
@@ -0,0 +1,54 @@
+package zakat
+
+import "testing"
+
+func TestCalculateStockOptionInTheMoney(t *testing.T) {
+	result, err := CalculateStockOption(StockOptionInput{
+		VestedShares:  "1000",
+		StrikePrice:   "12.50",
+		MarketPrice:   "40",
+		Exercisable:   true,
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "27500", "intrinsic value mismatch")
+	assertDecimalEqual(t, result.ZakatDue, "687.5", "zakat_due mismatch")
+}
+
+func TestCalculateStockOptionUnderwater(t *testing.T) {
+	result, err := CalculateStockOption(StockOptionInput{
+		VestedShares:  "1000",
+		StrikePrice:   "40",
+		MarketPrice:   "25",
+		Exercisable:   true,
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Error("underwater options should not be payable")
+	}
+	assertDecimalEqual(t, result.ZakatDue, "0", "zakat_due mismatch")
+	if result.DeferredAmount != "" {
+		t.Errorf("underwater options defer nothing, got %s", result.DeferredAmount)
+	}
+}
+
+func TestCalculateStockOptionUnexercisable(t *testing.T) {
+	result, err := CalculateStockOption(StockOptionInput{
+		VestedShares:  "1000",
+		StrikePrice:   "10",
+		MarketPrice:   "30",
+		HawlSatisfied: true,
+	}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	if result.IsPayable {
+		t.Error("unexercisable options should not be payable now")
+	}
+	assertDecimalEqual(t, result.DeferredAmount, "20000", "deferred intrinsic value mismatch")
+}
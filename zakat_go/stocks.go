@@ -0,0 +1,139 @@
+package zakat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Stock holding modes accepted in StockHolding.Mode.
+const (
+	StockModeTrading    = "Trading"
+	StockModeInvestment = "Investment"
+)
+
+// StockHolding is one position in a StockPortfolioInput.
+type StockHolding struct {
+	// Name - ticker or company name
+	Name string
+	// MarketValue - current market value of the position
+	MarketValue string
+	// Mode - "Trading" for shares bought to resell, zakated at full market
+	// value, or "Investment" for long-term holdings, zakated on the
+	// company's zakatable assets per share. Empty means "Trading".
+	Mode string
+	// ZakatableRatio - for Investment holdings, the share of the company's
+	// value held in zakatable assets (cash, receivables, inventory), as a
+	// fraction or percent. Required for Investment; ignored for Trading.
+	ZakatableRatio string
+}
+
+// StockPortfolioInput holds a mix of trading and investment stocks, pooled
+// for one nisab test.
+type StockPortfolioInput struct {
+	// Holdings - stock positions
+	Holdings []StockHolding
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Validate checks that all holding values and ratios are valid, every
+// Investment holding has a ratio, and liabilities are non-negative decimals.
+func (in StockPortfolioInput) Validate() error {
+	_, err := in.parse()
+	return err
+}
+
+// stockValues holds the parsed fields of a StockPortfolioInput.
+type stockValues struct {
+	market, zakatable     []decimal.Decimal
+	investment            []bool
+	liabilities, disputed decimal.Decimal
+}
+
+func (in StockPortfolioInput) parse() (v stockValues, err error) {
+	for i, holding := range in.Holdings {
+		field := fmt.Sprintf("holdings[%d]", i)
+		market, err := parseAmount(field+".market_value", holding.MarketValue)
+		if err != nil {
+			return v, err
+		}
+		zakatable, investment := market, false
+		switch strings.TrimSpace(holding.Mode) {
+		case "", StockModeTrading:
+		case StockModeInvestment:
+			if strings.TrimSpace(holding.ZakatableRatio) == "" {
+				return v, fieldError(ErrInconsistentInput, field+".zakatable_ratio", holding.ZakatableRatio)
+			}
+			ratio, err := parseFractionField(field+".zakatable_ratio", holding.ZakatableRatio)
+			if err != nil {
+				return v, err
+			}
+			if ratio.GreaterThan(decimal.NewFromInt(1)) {
+				return v, fieldError(ErrInvalidFraction, field+".zakatable_ratio", holding.ZakatableRatio)
+			}
+			zakatable, investment = market.Mul(ratio), true
+		default:
+			return v, fieldError(ErrInvalidOption, field+".mode", holding.Mode)
+		}
+		v.market = append(v.market, market)
+		v.zakatable = append(v.zakatable, zakatable)
+		v.investment = append(v.investment, investment)
+	}
+	if v.liabilities, err = parseAmount("liabilities", in.Liabilities); err != nil {
+		return
+	}
+	v.disputed, err = parseAmount("disputed_liabilities", in.DisputedLiabilities)
+	return
+}
+
+// CalculateStockPortfolio calculates zakat on a stock portfolio: trading
+// holdings count at full market value and investment holdings at market
+// value x zakatable ratio. The holdings are pooled for one nisab test
+// against the monetary nisab, with one breakdown line per holding.
+func CalculateStockPortfolio(input StockPortfolioInput, config Config) (ZakatResult, error) {
+	v, err := input.parse()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	rules, err := config.rules()
+	if err != nil {
+		return ZakatResult{}, err
+	}
+	nisab, err := monetaryNisab(config, rules)
+	if err != nil {
+		return ZakatResult{}, err
+	}
+
+	var breakdown []BreakdownLine
+	total := decimal.Zero
+	for i, holding := range input.Holdings {
+		if v.investment[i] {
+			breakdown = append(breakdown,
+				amountLine("step-stock-market-value", "Investment: "+holding.Name, v.market[i], OpInfo),
+				amountLine("step-stock-investment", "Zakatable Share: "+holding.Name, v.zakatable[i], OpAdd),
+			)
+		} else {
+			breakdown = append(breakdown, amountLine("step-stock-trading", "Trading: "+holding.Name, v.market[i], OpAdd))
+		}
+		total = total.Add(v.zakatable[i])
+	}
+	breakdown = append(breakdown, amountLine("step-total-stocks", "Total Zakatable Stocks", total, OpResult))
+
+	return calculateMonetary(monetaryParams{
+		totalAssets:   total,
+		liabilities:   v.liabilities,
+		disputed:      v.disputed,
+		nisab:         nisab,
+		rate:          rules.tradeGoodsRate,
+		hawlSatisfied: input.HawlSatisfied,
+		assetType:     AssetTypeStocks,
+		breakdown:     breakdown,
+		config:        config,
+	})
+}
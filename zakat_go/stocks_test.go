@@ -0,0 +1,49 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalculateStockPortfolioMixed(t *testing.T) {
+	input := StockPortfolioInput{
+		Holdings: []StockHolding{
+			{Name: "ACME", MarketValue: "6000", Mode: StockModeTrading},
+			{Name: "GLOBEX", MarketValue: "20000", Mode: StockModeInvestment, ZakatableRatio: "20%"},
+		},
+		HawlSatisfied: true,
+	}
+	result, err := CalculateStockPortfolio(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	assertDecimalEqual(t, result.TotalAssets, "10000", "trading at full value plus 20% of investment")
+	assertDecimalEqual(t, result.ZakatDue, "250", "zakat_due mismatch")
+
+	perHolding := map[string]string{}
+	for _, line := range result.Breakdown {
+		perHolding[line.Key] = line.Amount
+	}
+	assertDecimalEqual(t, perHolding["step-stock-trading"], "6000", "trading holding line")
+	assertDecimalEqual(t, perHolding["step-stock-investment"], "4000", "investment holding line")
+}
+
+func TestStockPortfolioInvestmentNeedsRatio(t *testing.T) {
+	input := StockPortfolioInput{Holdings: []StockHolding{{Name: "GLOBEX", MarketValue: "20000", Mode: StockModeInvestment}}}
+	if err := input.Validate(); !errors.Is(err, ErrInconsistentInput) {
+		t.Errorf("expected ErrInconsistentInput, got %v", err)
+	}
+	input.Holdings[0].Mode = "Hedging"
+	if err := input.Validate(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestStockPortfolioRejectsBareRatioAboveOne(t *testing.T) {
+	for _, ratio := range []string{"1.5", "150%"} {
+		input := StockPortfolioInput{Holdings: []StockHolding{{Name: "GLOBEX", MarketValue: "20000", Mode: StockModeInvestment, ZakatableRatio: ratio}}}
+		if err := input.Validate(); !errors.Is(err, ErrInvalidFraction) {
+			t.Errorf("%q: expected ErrInvalidFraction, got %v", ratio, err)
+		}
+	}
+}
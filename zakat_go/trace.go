@@ -0,0 +1,78 @@
+//go:build purego
+
+package zakat
+
+import "fmt"
+
+// TraceDiff is the line-by-line comparison of the FFI and pure-Go
+// breakdowns of one calculation.
+type TraceDiff struct {
+	// FFIDue - zakat due from the FFI backend
+	FFIDue string
+	// GoDue - zakat due from the pure-Go port
+	GoDue string
+	// Lines - the breakdown lines that differ, in breakdown order
+	Lines []LineDiff
+}
+
+// LineDiff is one breakdown position where the backends disagree. A side
+// that has no line at that position is nil.
+type LineDiff struct {
+	// Index - position in the breakdown
+	Index int
+	// FFI - the FFI backend's line
+	FFI *BreakdownLine
+	// Go - the pure-Go port's line
+	Go *BreakdownLine
+}
+
+// Equal reports whether the two backends agree on every line and the due.
+func (d TraceDiff) Equal() bool {
+	return len(d.Lines) == 0 && ToDecimal(d.FFIDue).Equal(ToDecimal(d.GoDue))
+}
+
+// TraceCompare calculates input through both the FFI backend and the
+// pure-Go port and reports every breakdown line on which they diverge, for
+// debugging binding discrepancies. Lines are compared by position on key,
+// operation and amount (numerically, so "10.0" matches "10"); labels are
+// ignored since they may be localized.
+//
+// It is a developer tool, built only with the purego tag, and returns
+// ErrFFIUnavailable when the FFI backend is not loaded.
+func TraceCompare(input any, config Config) (TraceDiff, error) {
+	if ffiCalculate == nil || !FFIAvailable() {
+		return TraceDiff{}, ErrFFIUnavailable
+	}
+	goResult, err := dispatchInput(input, config)
+	if err != nil {
+		return TraceDiff{}, fmt.Errorf("pure-Go: %w", err)
+	}
+	ffiMu.Lock()
+	ffiResult, err := ffiCalculate(input, config)
+	ffiMu.Unlock()
+	if err != nil {
+		return TraceDiff{}, fmt.Errorf("ffi: %w", err)
+	}
+
+	diff := TraceDiff{FFIDue: ffiResult.ZakatDue, GoDue: goResult.ZakatDue}
+	for i := 0; i < max(len(ffiResult.Breakdown), len(goResult.Breakdown)); i++ {
+		var ffiLine, goLine *BreakdownLine
+		if i < len(ffiResult.Breakdown) {
+			ffiLine = &ffiResult.Breakdown[i]
+		}
+		if i < len(goResult.Breakdown) {
+			goLine = &goResult.Breakdown[i]
+		}
+		if !sameLine(ffiLine, goLine) {
+			diff.Lines = append(diff.Lines, LineDiff{Index: i, FFI: ffiLine, Go: goLine})
+		}
+	}
+	return diff, nil
+}
+
+func sameLine(a, b *BreakdownLine) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Key == b.Key && a.Op == b.Op && ToDecimal(a.Amount).Equal(ToDecimal(b.Amount))
+}
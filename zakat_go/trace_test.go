@@ -0,0 +1,76 @@
+//go:build purego
+
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTraceCompareReferenceVectors(t *testing.T) {
+	withFFIBackend(t, dispatchInput)
+	config := NewConfig("100", "1")
+	for _, tc := range selfTestCases {
+		diff, err := TraceCompare(tc.input, config)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !diff.Equal() {
+			t.Errorf("%s: backends diverge: %+v", tc.name, diff)
+		}
+	}
+}
+
+func TestTraceCompareReportsDivergentLine(t *testing.T) {
+	withFFIBackend(t, func(input any, config Config) (ZakatResult, error) {
+		result, err := dispatchInput(input, config)
+		result.Breakdown = append([]BreakdownLine(nil), result.Breakdown...)
+		result.Breakdown[0].Amount = "1"
+		return result, err
+	})
+	diff, err := TraceCompare(CashInput{CashOnHand: "10000", HawlSatisfied: true}, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("TraceCompare failed: %v", err)
+	}
+	if len(diff.Lines) != 1 || diff.Lines[0].Index != 0 || diff.Lines[0].Go.Amount != "10000" {
+		t.Errorf("expected only line 0 to diverge, got %+v", diff.Lines)
+	}
+}
+
+func TestTraceCompareReportsDivergentDueAndExtraLine(t *testing.T) {
+	withFFIBackend(t, func(input any, config Config) (ZakatResult, error) {
+		result, err := dispatchInput(input, config)
+		result.ZakatDue = "251"
+		result.Breakdown = append(append([]BreakdownLine(nil), result.Breakdown...), infoLine("step-ffi-only", "FFI Only"))
+		return result, err
+	})
+	input := CashInput{CashOnHand: "10000", HawlSatisfied: true}
+	goResult, err := dispatchInput(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("calculation failed: %v", err)
+	}
+	diff, err := TraceCompare(input, NewConfig("100", "1"))
+	if err != nil {
+		t.Fatalf("TraceCompare failed: %v", err)
+	}
+	if diff.Equal() {
+		t.Fatalf("divergent backends should not compare equal")
+	}
+	if diff.FFIDue != "251" || diff.GoDue != "250" {
+		t.Errorf("expected dues ffi=251 go=250, got ffi=%s go=%s", diff.FFIDue, diff.GoDue)
+	}
+	if len(diff.Lines) != 1 {
+		t.Fatalf("expected only the extra line to diverge, got %+v", diff.Lines)
+	}
+	line := diff.Lines[0]
+	if line.Index != len(goResult.Breakdown) || line.FFI == nil || line.FFI.Key != "step-ffi-only" || line.Go != nil {
+		t.Errorf("expected the FFI-only line past the Go breakdown, got %+v", line)
+	}
+}
+
+func TestTraceCompareNeedsFFI(t *testing.T) {
+	withFFIProbe(t, func() bool { return false })
+	if _, err := TraceCompare(CashInput{}, NewConfig("100", "1")); !errors.Is(err, ErrFFIUnavailable) {
+		t.Errorf("expected ErrFFIUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package zakat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// validator is implemented by every typed input.
+type validator interface {
+	Validate() error
+}
+
+// inputTypes maps each canonical asset type to a constructor for its typed
+// input, for decoding inputs that arrive as JSON.
+var inputTypes = map[string]func() validator{
+	AssetTypeBusiness:        func() validator { return &BusinessInput{} },
+	AssetTypeGold:            func() validator { return &GoldInput{} },
+	AssetTypeSilver:          func() validator { return &SilverInput{} },
+	AssetTypeCash:            func() validator { return &CashInput{} },
+	AssetTypePropertyForSale: func() validator { return &PropertyForSaleInput{} },
+	AssetTypeEndOfService:    func() validator { return &EndOfServiceInput{} },
+	AssetTypeCrypto:          func() validator { return &CryptoInput{} },
+	AssetTypeCommodity:       func() validator { return &CommodityInput{} },
+	AssetTypeAgriculture:     func() validator { return &AgricultureInput{} },
+	AssetTypeStocks:          func() validator { return &StockPortfolioInput{} },
+	AssetTypeCooperative:     func() validator { return &CooperativeInput{} },
+	AssetTypeInsurance:       func() validator { return &InsuranceInput{} },
+	AssetTypeFitr:            func() validator { return &FitrInput{} },
+	AssetTypeGemstone:        func() validator { return &GemstoneInput{} },
+	AssetTypeCrowdfunding:    func() validator { return &CrowdfundingInput{} },
+	AssetTypeStockOption:     func() validator { return &StockOptionInput{} },
+}
+
+// ValidateInput decodes inputJSON as the typed input for assetType (a
+// canonical asset type or alias) and validates it without calculating, for
+// inline form validation. Keys are matched to the input's field names case-
+// insensitively and may be in snake_case, e.g. "weight_grams" or
+// "WeightGrams".
+//
+// Every invalid top-level field is reported, in the order the input's
+// Validate finds them; validation stops at the first error that is not tied
+// to a top-level field. A nil result means the input is valid.
+func ValidateInput(assetType string, inputJSON []byte) []ValidationError {
+	canonical, ok := resolveAssetType(assetType)
+	newInput := inputTypes[canonical]
+	if !ok || newInput == nil {
+		return []ValidationError{{Field: "asset_type", Value: assetType, Err: ErrInvalidOption}}
+	}
+	input := newInput()
+	if err := decodeInput(inputJSON, input); err != nil {
+		return []ValidationError{decodeError(err, reflect.TypeOf(input).Elem())}
+	}
+
+	var errs []ValidationError
+	v := reflect.ValueOf(input).Elem()
+	for {
+		err := input.Validate()
+		if err == nil {
+			return errs
+		}
+		var ve ValidationError
+		if !errors.As(err, &ve) {
+			return append(errs, ValidationError{Err: err})
+		}
+		errs = append(errs, ve)
+		// Blank the offending field and validate again to find the next.
+		field, ok := stringField(v, ve.Field)
+		if !ok || field.String() == "" {
+			return errs
+		}
+		field.SetString("")
+	}
+}
+
+// decodeInput unmarshals data into input after folding snake_case keys into
+// the field-name form encoding/json matches case-insensitively.
+func decodeInput(data []byte, input any) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	folded, err := json.Marshal(foldKeys(raw))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(folded, input)
+}
+
+// foldKeys removes underscores from every object key, recursively.
+func foldKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[strings.ReplaceAll(key, "_", "")] = foldKeys(value)
+		}
+		return out
+	case []any:
+		for i := range v {
+			v[i] = foldKeys(v[i])
+		}
+	}
+	return v
+}
+
+// decodeError reports a JSON decoding failure on an input of type t as a
+// validation error.
+func decodeError(err error, t reflect.Type) ValidationError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		// The error names the folded key; report the field it matched.
+		field := typeErr.Field
+		if f, ok := t.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, field) }); ok {
+			field = snakeCase(f.Name)
+		}
+		return ValidationError{Field: field, Value: typeErr.Value, Err: fmt.Errorf("%w: %v", ErrUnsupportedInput, err)}
+	}
+	return ValidationError{Field: "input", Err: fmt.Errorf("%w: %v", ErrUnsupportedInput, err)}
+}
+
+// stringField returns the top-level string field of struct v whose
+// snake_case name is name.
+func stringField(v reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if snakeCase(v.Type().Field(i).Name) == name && v.Field(i).Kind() == reflect.String {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// snakeCase converts a Go field name, or a dotted path of them, to the
+// snake_case used in error fields: "WeightGrams" becomes "weight_grams".
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && name[i-1] != '.' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package zakat
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateInputMalformedGold(t *testing.T) {
+	errs := ValidateInput("gold", []byte(`{"weight_grams": "-5", "purity": "abc", "usage": "Decorative", "liabilities": "x", "hawl_satisfied": true}`))
+
+	want := []struct {
+		field string
+		err   error
+	}{
+		{"weight_grams", ErrNegativeValue},
+		{"purity", ErrInvalidDecimal},
+		{"usage", ErrInvalidUsage},
+		{"liabilities", ErrInvalidDecimal},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d field errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if errs[i].Field != w.field || !errors.Is(errs[i], w.err) {
+			t.Errorf("error %d: expected %s (%v), got %s (%v)", i, w.field, w.err, errs[i].Field, errs[i].Err)
+		}
+	}
+}
+
+func TestValidateInputValidAndAliases(t *testing.T) {
+	if errs := ValidateInput("savings", []byte(`{"CashOnHand": "1000", "bank_accounts": [{"name": "Main", "balance": "50"}]}`)); errs != nil {
+		t.Errorf("expected a valid cash input, got %v", errs)
+	}
+	if errs := ValidateInput("savings", []byte(`{"bank_accounts": [{"name": "Main", "balance": "-50"}]}`)); len(errs) != 1 || !errors.Is(errs[0], ErrNegativeValue) {
+		t.Errorf("expected one nested balance error, got %v", errs)
+	}
+	if errs := ValidateInput("yachts", []byte(`{}`)); len(errs) != 1 || errs[0].Field != "asset_type" {
+		t.Errorf("expected an asset_type error, got %v", errs)
+	}
+	if errs := ValidateInput("gold", []byte(`{"weight_grams": 100}`)); len(errs) != 1 || !errors.Is(errs[0], ErrUnsupportedInput) || errs[0].Field != "weight_grams" {
+		t.Errorf("expected a type error on weight_grams, got %v", errs)
+	}
+}
+
+func TestInputTypesCoverCanonicalAssetTypes(t *testing.T) {
+	for assetType := range canonicalAssetTypes {
+		if inputTypes[assetType] == nil {
+			t.Errorf("no input type registered for %q", assetType)
+		}
+	}
+}
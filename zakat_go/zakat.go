@@ -36,9 +36,21 @@
 // precision. For calculations in Go, use shopspring/decimal:
 //
 //	zakatDue, _ := decimal.NewFromString(result.ZakatDue)
+//
+// # Calculation Backend
+//
+// Until uniffi-bindgen-go generates the FFI bindings, the calculators in this
+// package run on a pure-Go port of the zakat-core rules. The port follows the
+// same valuation, nisab and rate logic as the Rust library. The generated
+// compliance tests do not exercise it yet; they wait on the bindings.
+//
+// Building with the purego tag adds TraceCompare, which runs an input through
+// both backends and reports where their breakdowns diverge.
 package zakat
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -77,8 +89,172 @@ type Config struct {
 	GoldPricePerGram string
 	// SilverPricePerGram is the current silver price per gram
 	SilverPricePerGram string
+	// GoldBidPricePerGram and GoldAskPricePerGram are the dealer buy (bid)
+	// and sell (ask) quotes for gold. When set, they take precedence over
+	// GoldPricePerGram according to MetalPriceBasis.
+	GoldBidPricePerGram string
+	GoldAskPricePerGram string
+	// SilverBidPricePerGram and SilverAskPricePerGram are the bid and ask
+	// quotes for silver, used like the gold quotes.
+	SilverBidPricePerGram string
+	SilverAskPricePerGram string
+	// MetalPriceBasis selects which quote values metals when both bid and
+	// ask are given. Empty means PriceBasisBid.
+	MetalPriceBasis MetalPriceBasis
+	// GoldBuyBackPricePerGram and SilverBuyBackPricePerGram are a jeweler's
+	// buy-back prices, quoted alongside the market price. They value gold
+	// and silver holdings (and the metal's gram nisab) when
+	// MetalValuationPrice selects them; the monetary nisab for cash always
+	// uses the market price.
+	GoldBuyBackPricePerGram   string
+	SilverBuyBackPricePerGram string
+	// MetalValuationPrice selects the market or buy-back price for metal
+	// holdings when both are given. Empty means ValuationMarket.
+	MetalValuationPrice MetalValuation
 	// Madhab specifies the Islamic school of jurisprudence (hanafi, shafi, maliki, hanbali)
-	Madhab string
+	Madhab Madhab
+	// NisabBasis overrides the madhab's monetary nisab basis, e.g. for an
+	// authority that fixes the nisab on gold. Empty uses the madhab's.
+	NisabBasis NisabBasis
+	// NisabUnit selects gram (the default) or tola nisab weights; see
+	// NisabUnitTola.
+	NisabUnit NisabUnit
+	// NisabRoundingDirection rounds the monetary nisab to whole currency
+	// units before the payability test: down to protect the poor, up to
+	// protect the payer. Empty (RoundNisabNone) compares exactly. Metal
+	// holdings keep their exact gram x price nisab.
+	NisabRoundingDirection NisabRounding
+	// DeductOperatingReserve excludes BusinessInput.OperatingReserve from
+	// zakatable cash.
+	//
+	// This is a debated position: the majority view zakats all cash on hand,
+	// while some contemporary scholars treat cash strictly needed to keep the
+	// business running as a working necessity (hajah asliyyah). Off by default.
+	DeductOperatingReserve bool
+	// IncludeDisputedLiabilities deducts DisputedLiabilities alongside
+	// undisputed debts. Off by default: a debt the user is unsure they owe is
+	// cautiously not allowed to reduce zakat.
+	IncludeDisputedLiabilities bool
+	// UseMinimumBalance makes cash payable only if the minimum of
+	// CashInput.DailyBalances also meets the nisab, for the ruling that
+	// wealth must stay above the nisab throughout the hawl. Zakat is still
+	// levied on the year-end net assets. In a portfolio the test applies to
+	// the pooled balance, counting other holdings at their net assets. Off
+	// by default.
+	UseMinimumBalance bool
+	// PaymentRounding is the rounding applied when converting amounts to
+	// currency minor units, as in ZakatResult.DueMinorUnits. Empty means
+	// RoundHalfUp.
+	PaymentRounding Rounding
+	// RoundingByAssetType overrides PaymentRounding for results of the
+	// given asset types (AssetTypeAgriculture, ...), e.g. to round
+	// agriculture down and cash up in one statement. Types not listed use
+	// PaymentRounding.
+	RoundingByAssetType map[string]Rounding
+	// StoredProduceAsTradeGoods values produce held past harvest for sale
+	// (AgricultureInput.HeldForSale) as trade goods at 2.5% instead of the
+	// in-kind ushr.
+	//
+	// The ushr is due once, at harvest; produce then kept with the intention
+	// of sale becomes merchandise (urud al-tijarah) and is zakated as such in
+	// later years, the view of the Maliki school and many contemporary
+	// scholars. Off by default.
+	StoredProduceAsTradeGoods bool
+	// FutureReceivablePolicy decides whether BusinessInput.ReceivableItems
+	// due more than one hawl after the assessment date are zakated now.
+	// Empty (FutureReceivableIncludeNow) includes them.
+	FutureReceivablePolicy FutureReceivablePolicy
+	// AgricultureNisabPolicy selects whether harvests must reach the
+	// 653 kg produce nisab. Empty (AgricultureStandardNisab) applies it;
+	// AgricultureNoNisab zakats any harvest, for the minority view.
+	AgricultureNisabPolicy AgricultureNisabPolicy
+	// DebtOffsetScope controls whether business liabilities reduce the
+	// zakatable base in full (DebtOffsetLiquidOnly, the default) or only in
+	// proportion to zakatable assets when BusinessInput.FixedAssets are
+	// declared (DebtOffsetAllAssets). The scope is applied first; net assets
+	// are then clamped at zero as usual. Disputed liabilities follow the
+	// same scope.
+	DebtOffsetScope DebtOffsetScope
+	// PersonalExemption is a flat amount some national guidelines exempt
+	// before zakat applies. It is subtracted from net assets that meet the
+	// nisab (floored at zero) before the rate; it is not a second nisab.
+	// Empty means no exemption.
+	PersonalExemption string
+	// NisabResolver, when set, determines the monetary nisab instead of the
+	// built-in PriceNisabResolver. Metal holdings still use their own
+	// weight nisab.
+	NisabResolver NisabResolver
+	// CharityCountsTowardZakat credits CharityGivenThisYear against the
+	// zakat due, as zakat paid in advance.
+	//
+	// Voluntary charity (sadaqah) does not discharge zakat: zakat requires
+	// the intention (niyyah) of zakat when it is paid or set aside. Charity
+	// given with that intention before the due date is advance zakat (ta'jil
+	// al-zakah), accepted by the Hanafi, Shafi'i and Hanbali schools. Set
+	// this only when the charity given was zakat-intended. Off by default:
+	// the charity is reported but not deducted.
+	CharityCountsTowardZakat bool
+	// VerifyResults recomputes every FFI result in pure Go and fails with
+	// ErrResultMismatch if the zakat due differs by more than
+	// VerifyTolerance, a safety net for binding or core bugs. It applies to
+	// the generic entry points (Calculate, CalculateContext, Recompute, ...)
+	// and does nothing while the FFI backend is unavailable. Off by default.
+	VerifyResults bool
+	// VerifyTolerance is the largest accepted difference under
+	// VerifyResults. Empty means 0.0000001.
+	VerifyTolerance string
+	// IntermediateScale rounds the intermediate metal products (pure
+	// weight, and pure weight x price) half-up to this many decimal places,
+	// to match an authority's rounding convention; a pointer to 0 rounds to
+	// whole units. Nil, the default, keeps full precision. Rounding before
+	// the nisab test can shift a holding right at the nisab across it.
+	IntermediateScale *int
+	// HawlDays is the length of the hawl in days used by the hawl helpers
+	// (HawlDueDate, HawlComplete). Zero means 354.37, the mean lunar year;
+	// set 354 or 355 to follow an authority that counts whole days, or
+	// 365.25 for a solar year. Values above 400 are rejected.
+	HawlDays float64
+	// MetalNisabInGrams makes CalculateGold and CalculateSilver test the
+	// nisab on the pure weight held (85g gold, 595g silver) rather than on
+	// net value, so payability does not move with the price. Liabilities
+	// still reduce the zakatable value but not the test. In a portfolio,
+	// gold and silver then keep their own weight test and are not pooled
+	// with the other monetary assets.
+	MetalNisabInGrams bool
+	// PeriodEnd is the date the zakat books close, the as-of date for
+	// HawlCompleteAtPeriodEnd and the price-age warning of Validate, so every
+	// calculation in a statement or batch is valued on the same date. Zero
+	// means the current time.
+	PeriodEnd time.Time
+	// PricesAt is when the metal prices were quoted. With MaxPriceAge, it
+	// lets Validate warn about prices older than that at PeriodEnd.
+	PricesAt time.Time
+	// MaxPriceAge is the oldest price quote Validate accepts without a
+	// warning. Zero disables the check.
+	MaxPriceAge time.Duration
+	// ZakatAsLiability deducts PriorUnpaidZakat, last year's zakat still
+	// owed, from this year's base like any other debt, as some accounting
+	// standards do. Off by default: the unpaid zakat is reported but not
+	// deducted.
+	ZakatAsLiability bool
+	// BaseCurrency is the currency results are stated in (ISO 4217). Amounts
+	// in it need no FX rate.
+	BaseCurrency string
+	// FXRates maps ISO 4217 codes to the value of one unit in BaseCurrency,
+	// e.g. {"USD": "16000"} for a rupiah base. Used to convert amounts
+	// stated in another currency, such as BusinessInput.InventoryCurrency.
+	FXRates map[string]string
+	// FXScale is the number of decimal places converted amounts are rounded
+	// to (half up); a pointer to 0 rounds to whole units. Nil uses
+	// BaseCurrency's minor units, e.g. 2 for USD and 0 for JPY; an unknown
+	// or empty BaseCurrency keeps the exact product.
+	FXScale *int
+	// PortfolioHawlPolicy decides how CalculatePortfolio treats components
+	// acquired at different times by combining their HawlSatisfied flags.
+	// Empty (HawlPerComponent) uses each component's own HawlSatisfied.
+	PortfolioHawlPolicy PortfolioHawlPolicy
+	// PortfolioHawlAnchor is the start of the shared hawl under HawlShared.
+	PortfolioHawlAnchor time.Time
 }
 
 // NewConfig creates a new Config with default Hanafi madhab.
@@ -86,12 +262,18 @@ func NewConfig(goldPrice, silverPrice string) Config {
 	return Config{
 		GoldPricePerGram:   goldPrice,
 		SilverPricePerGram: silverPrice,
-		Madhab:             "hanafi",
+		Madhab:             MadhabHanafi,
 	}
 }
 
-// WithMadhab returns a copy of the config with the specified madhab.
+// WithMadhab returns a copy of the config with the specified madhab, given
+// by name ("hanafi", "shafi", "maliki", "hanbali").
 func (c Config) WithMadhab(madhab string) Config {
+	return c.WithMadhabType(Madhab(madhab))
+}
+
+// WithMadhabType returns a copy of the config with the specified madhab.
+func (c Config) WithMadhabType(madhab Madhab) Config {
 	c.Madhab = madhab
 	return c
 }
@@ -104,10 +286,39 @@ type BusinessInput struct {
 	InventoryValue string
 	// Receivables - money owed to the business
 	Receivables string
+	// ReceivableItems - receivables listed with their due dates, added to
+	// Receivables; see Config.FutureReceivablePolicy
+	ReceivableItems []Receivable
 	// Liabilities - debts due now that should be deducted
 	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
 	// HawlSatisfied - whether one lunar year has passed
 	HawlSatisfied bool
+	// OperatingReserve - cash set aside as working capital for operations.
+	// Only deducted from cash when Config.DeductOperatingReserve is set.
+	OperatingReserve string
+	// FixedAssets - declared non-zakatable assets (premises, equipment).
+	// Never zakated; only affects liabilities under Config.DebtOffsetScope.
+	FixedAssets string
+	// CharityGivenThisYear - charity given during the year, see
+	// Config.CharityCountsTowardZakat
+	CharityGivenThisYear string
+	// NetProfit - the year's profit or loss from the accounts, may be
+	// negative. Informational only: zakat is on net assets, so a loss does
+	// not exempt the business.
+	NetProfit string
+	// PriorUnpaidZakat - last year's zakat not yet paid, see
+	// Config.ZakatAsLiability
+	PriorUnpaidZakat string
+	// TaxesDue - taxes assessed and currently payable but unpaid, deducted
+	// like short-term liabilities. Estimated tax on future income is not a
+	// current debt and should not be included.
+	TaxesDue string
+	// InventoryCurrency - currency InventoryValue is stated in (ISO 4217,
+	// e.g. "USD"), converted with Config.FXRates. Empty means the base
+	// currency.
+	InventoryCurrency string
 }
 
 // GoldInput holds input values for gold zakat calculation.
@@ -118,8 +329,19 @@ type GoldInput struct {
 	Purity string
 	// Usage - "Investment" or "PersonalUse"
 	Usage string
+	// InvestmentFraction - share of the holding kept as investment, from "0"
+	// to "1" (percent forms like "50%" are accepted); the rest is
+	// personal use. Empty derives it from Usage.
+	InvestmentFraction string
+	// OwnershipFraction - the user's share of jointly-owned metal, such as
+	// jewelry owned with a spouse, from "0" to "1" (percent forms like
+	// "50%" are accepted); only that share of the weight is valued. Empty
+	// means "1" (sole owner).
+	OwnershipFraction string
 	// Liabilities - debts due now
 	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
 	// HawlSatisfied - whether one lunar year has passed
 	HawlSatisfied bool
 }
@@ -132,15 +354,119 @@ type SilverInput struct {
 	Purity string
 	// Usage - "Investment" or "PersonalUse"
 	Usage string
+	// InvestmentFraction - share of the holding kept as investment, from "0"
+	// to "1" (percent forms like "50%" are accepted); the rest is
+	// personal use. Empty derives it from Usage.
+	InvestmentFraction string
+	// OwnershipFraction - the user's share of jointly-owned metal, such as
+	// jewelry owned with a spouse, from "0" to "1" (percent forms like
+	// "50%" are accepted); only that share of the weight is valued. Empty
+	// means "1" (sole owner).
+	OwnershipFraction string
 	// Liabilities - debts due now
 	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
 	// HawlSatisfied - whether one lunar year has passed
 	HawlSatisfied bool
 }
 
+// CashAccount is a named cash balance, such as a bank or savings account.
+type CashAccount struct {
+	// Name - account label shown in the breakdown
+	Name string
+	// Balance - current balance of the account
+	Balance string
+	// OwnershipFraction - the holder's share of a joint account, as a
+	// fraction or percent. Only that share of the balance is counted,
+	// split to the minor unit of Config.BaseCurrency. Empty means "1"
+	// (sole owner).
+	OwnershipFraction string
+}
+
+// CashInput holds input values for cash and savings zakat calculation.
+type CashInput struct {
+	// CashOnHand - physical cash held
+	CashOnHand string
+	// BankAccounts - bank and savings balances
+	BankAccounts []CashAccount
+	// EWalletBalances - e-money and digital wallet balances (GoPay, OVO,
+	// DANA, PayPal), liquid cash treated exactly like bank balances
+	EWalletBalances []CashAccount
+	// AccruedSalary - salary earned but not yet paid, expected in full. A
+	// debt owed by a solvent payer (dayn qawi) is zakatable now.
+	AccruedSalary string
+	// Liabilities - debts due now
+	Liabilities string
+	// DisputedLiabilities - contested debts, see Config.IncludeDisputedLiabilities
+	DisputedLiabilities string
+	// DeferredPurchaseObligations - committed installment or layaway
+	// payments, deducted like short-term liabilities
+	DeferredPurchaseObligations string
+	// CharityGivenThisYear - charity given during the year, see
+	// Config.CharityCountsTowardZakat
+	CharityGivenThisYear string
+	// PriorUnpaidZakat - last year's zakat not yet paid, see
+	// Config.ZakatAsLiability
+	PriorUnpaidZakat string
+	// TaxesDue - taxes assessed and currently payable but unpaid, deducted
+	// like short-term liabilities. Estimated tax on future income is not a
+	// current debt and should not be included.
+	TaxesDue string
+	// DailyBalances - end-of-day total balances over the hawl, used for
+	// payability under Config.UseMinimumBalance
+	DailyBalances []string
+	// AmanahHeld - funds held in trust for someone else (amanah) that are
+	// included in the balances above. They are not the holder's wealth and
+	// are excluded from the zakatable base.
+	AmanahHeld string
+	// BusinessCashFraction - share of the cash that belongs to the owner's
+	// business, as a fraction or percent, for statements that separate
+	// business from personal funds. Both shares are zakatable alike, so it
+	// only splits the total in the breakdown. Empty means all personal.
+	BusinessCashFraction string
+	// HawlSatisfied - whether one lunar year has passed
+	HawlSatisfied bool
+}
+
+// Asset types reported in ZakatResult.AssetType.
+const (
+	AssetTypeBusiness = "business"
+	AssetTypeGold     = "gold"
+	AssetTypeSilver   = "silver"
+	AssetTypeCash     = "cash"
+	// AssetTypePropertyForSale is real estate held for resale (trade goods).
+	AssetTypePropertyForSale = "property_for_sale"
+	// AssetTypeEndOfService is an accrued end-of-service gratuity.
+	AssetTypeEndOfService = "end_of_service"
+	// AssetTypeCrypto is crypto held as trade goods.
+	AssetTypeCrypto = "crypto"
+	// AssetTypeCommodity is a non-metal commodity held for trade.
+	AssetTypeCommodity = "commodity"
+	// AssetTypeAgriculture is harvested crops or fruit.
+	AssetTypeAgriculture = "agriculture"
+	// AssetTypeStocks is a portfolio of trading and investment stocks.
+	AssetTypeStocks = "stocks"
+	// AssetTypeCooperative is a cooperative or credit-union share balance.
+	AssetTypeCooperative = "cooperative"
+	// AssetTypeInsurance is the cash-surrender value of a takaful policy.
+	AssetTypeInsurance = "insurance"
+	// AssetTypeFitr is Zakat al-Fitr, paid per person.
+	AssetTypeFitr = "fitr"
+	// AssetTypeGemstone is precious stones; only stones held for trade are zakatable.
+	AssetTypeGemstone = "gemstone"
+	// AssetTypeCrowdfunding is a P2P lending or equity-crowdfunding position.
+	AssetTypeCrowdfunding = "crowdfunding"
+	// AssetTypeStockOption is vested employee stock options.
+	AssetTypeStockOption = "stock_option"
+)
+
 // ZakatResult holds the result of a zakat calculation.
 type ZakatResult struct {
-	// IsPayable - whether zakat is due (above nisab and hawl satisfied)
+	// AssetType - the kind of asset calculated (e.g. "business", "gold")
+	AssetType string
+	// IsPayable - whether zakat is due (at or above nisab and hawl satisfied).
+	// The nisab boundary is inclusive and compared exactly.
 	IsPayable bool
 	// ZakatDue - amount of zakat due (string for precision)
 	ZakatDue string
@@ -150,6 +476,71 @@ type ZakatResult struct {
 	NetAssets string
 	// NisabThreshold - the nisab threshold used for comparison
 	NisabThreshold string
+	// Breakdown - the calculation steps that produced the result
+	Breakdown []BreakdownLine
+	// Assumptions - policy choices and notes that affected the result
+	Assumptions []string
+	// DeferredAmount - value not zakatable yet because it is inaccessible;
+	// zakat is due on it once received (string for precision, empty if none)
+	DeferredAmount string
+	// ConfigSnapshot - the config the result was calculated with
+	ConfigSnapshot Config
+	// RequestID - correlation ID of the request that produced the result,
+	// set by the context-aware functions (see WithRequestID)
+	RequestID string
+	// InputsHash - HashInputs of the input and config, set by the generic
+	// entry points that take an input of any type (Calculate,
+	// CalculateContext, Recompute, ...)
+	InputsHash string
+}
+
+// Operation describes how a breakdown line contributes to the calculation.
+type Operation string
+
+const (
+	// OpAdd adds the amount to the running total.
+	OpAdd Operation = "add"
+	// OpSubtract deducts the amount from the running total.
+	OpSubtract Operation = "subtract"
+	// OpResult reports an intermediate or final total.
+	OpResult Operation = "result"
+	// OpCompare reports a threshold the total was compared against.
+	OpCompare Operation = "compare"
+	// OpRate reports the rate applied to the total.
+	OpRate Operation = "rate"
+	// OpInfo is an informational line without an amount.
+	OpInfo Operation = "info"
+)
+
+// Unit is the unit of a breakdown line's amount. The zero value is the
+// configured currency; the others are quantities rendered as plain numbers.
+type Unit string
+
+const (
+	// UnitCurrency is a monetary amount in the configured currency.
+	UnitCurrency Unit = ""
+	// UnitGrams is a weight in grams.
+	UnitGrams Unit = "g"
+	// UnitKg is a weight in kilograms.
+	UnitKg Unit = "kg"
+	// UnitTola is a weight in tola.
+	UnitTola Unit = "tola"
+	// UnitCount is a count of people, shares or other items.
+	UnitCount Unit = "count"
+)
+
+// BreakdownLine is a single step of a calculation breakdown.
+type BreakdownLine struct {
+	// Key - stable identifier for the step (e.g. "step-cash-on-hand")
+	Key string
+	// Label - human-readable description of the step
+	Label string
+	// Amount - value of the step (string for precision, empty for info lines)
+	Amount string
+	// Op - how the step contributes to the calculation
+	Op Operation
+	// Unit - unit of Amount (empty for currency amounts)
+	Unit Unit
 }
 
 // ZakatDueDecimal returns the ZakatDue as a shopspring/decimal.Decimal.
@@ -161,10 +552,3 @@ func (r ZakatResult) ZakatDueDecimal() decimal.Decimal {
 func (r ZakatResult) NetAssetsDecimal() decimal.Decimal {
 	return ToDecimal(r.NetAssets)
 }
-
-// TODO: The following functions will call into the UniFFI-generated bindings.
-// They are placeholders until uniffi-bindgen-go generates the actual bindings.
-//
-// func CalculateBusiness(input BusinessInput, config Config) (ZakatResult, error)
-// func CalculateGold(input GoldInput, config Config) (ZakatResult, error)
-// func CalculateSilver(input SilverInput, config Config) (ZakatResult, error)